@@ -4,6 +4,7 @@ import (
 	"errors"
 	"pandora-pay/config"
 	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/encryption"
 	"pandora-pay/helpers"
 )
 
@@ -35,7 +36,7 @@ func (pk *PrivateKey) Verify(message, signature []byte) bool {
 }
 
 func (pk *PrivateKey) Decrypt(message []byte) ([]byte, error) {
-	return nil, errors.New("Encryption is not supported right now")
+	return encryption.DecryptX25519(pk.Key, message)
 }
 
 func (pk *PrivateKey) Deserialize(buffer []byte) error {
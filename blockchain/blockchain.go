@@ -11,12 +11,10 @@ import (
 	"pandora-pay/blockchain/blocks/block/difficulty"
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/data_storage"
-	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/forging/forging_block_work"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/config"
-	"pandora-pay/config/config_coins"
 	"pandora-pay/config/config_stake"
 	"pandora-pay/gui"
 	"pandora-pay/helpers"
@@ -47,6 +45,7 @@ type Blockchain struct {
 	UpdateNewChainUpdate                    *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]
 	UpdateSocketsSubscriptionsTransactions  *multicast.MulticastChannel[[]*blockchain_types.BlockchainTransactionUpdate]
 	UpdateSocketsSubscriptionsNotifications *multicast.MulticastChannel[*data_storage.DataStorage]
+	UpdateSocketsSubscriptionsForgers       *multicast.MulticastChannel[[]*blockchain_types.BlockchainForgerUpdate]
 	NextBlockCreatedCn                      chan *forging_block_work.ForgingWork
 }
 
@@ -108,6 +107,7 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 	}
 
 	allTransactionsChanges := []*blockchain_types.BlockchainTransactionUpdate{}
+	allForgersChanges := []*blockchain_types.BlockchainForgerUpdate{}
 
 	insertedBlocks := []*block_complete.BlockComplete{}
 
@@ -130,8 +130,10 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 		err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
 
 			defer func() {
+				//the panics are returned as errors, hence the db tx is rolled back instead of being partially stored
 				if errReturned := recover(); errReturned != nil {
-					err = errReturned.(error)
+					err = fmt.Errorf("%v", errReturned)
+					gui.GUI.Error("Including blocks failed. The store can be checked using --verify-store", err)
 				}
 			}()
 
@@ -164,6 +166,10 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 			firstBlockComplete := blocksComplete[0]
 			if firstBlockComplete.Block.Height < newChainData.Height {
 
+				if err = chain.checkBlocksRemovable(writer, firstBlockComplete.Block.Height); err != nil {
+					return
+				}
+
 				index := newChainData.Height - 1
 				for {
 
@@ -175,6 +181,16 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 						return
 					}
 
+					if config.SEED_WALLET_NODES_INFO {
+						var forgerChange *blockchain_types.BlockchainForgerUpdate
+						if forgerChange, err = removeForgerBlock(writer, index); err != nil {
+							return
+						}
+						if forgerChange != nil {
+							allForgersChanges = append(allForgersChanges, forgerChange)
+						}
+					}
+
 					if index > firstBlockComplete.Block.Height {
 						index -= 1
 					} else {
@@ -221,25 +237,6 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 						return errors.New("Block Height is not right!")
 					}
 
-					//increase supply
-					var ast *asset.Asset
-					if ast, err = dataStorage.Asts.GetAsset(config_coins.NATIVE_ASSET_FULL); err != nil {
-						return
-					}
-
-					var reward, _ uint64
-					if reward, _, err = blockchain_types.ComputeBlockReward(blkComplete.Height, blkComplete.Txs); err != nil {
-						return
-					}
-
-					if err = ast.AddNativeSupply(true, reward); err != nil {
-						return
-					}
-					if err = dataStorage.Asts.Update(string(config_coins.NATIVE_ASSET_FULL), ast); err != nil {
-						return
-					}
-					newChainData.Supply = ast.Supply
-
 					var plainAcc *plain_account.PlainAccount
 					if plainAcc, err = dataStorage.PlainAccs.GetPlainAccount(blkComplete.Block.Forger); err != nil {
 						return
@@ -285,12 +282,8 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 						return errors.New("Timestamp is too much into the future")
 					}
 
-					if err = blkComplete.IncludeBlockComplete(dataStorage); err != nil {
-						return fmt.Errorf("Error including block %d into Blockchain: %s", blkComplete.Height, err.Error())
-					}
-
-					if err = dataStorage.ProcessPendingStakes(blkComplete.Height); err != nil {
-						return errors.New("Error Processing Pending Stakes: " + err.Error())
+					if newChainData.Supply, err = includeBlockCompleteState(blkComplete, dataStorage); err != nil {
+						return
 					}
 
 					//to detect if the savedBlock was done correctly
@@ -300,6 +293,22 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 						return errors.New("Error saving block complete: " + err.Error())
 					}
 
+					if config.SEED_WALLET_NODES_INFO {
+						var forgerChange *blockchain_types.BlockchainForgerUpdate
+						if forgerChange, err = saveForgerBlock(writer, blkComplete); err != nil {
+							return
+						}
+						allForgersChanges = append(allForgersChanges, forgerChange)
+					}
+
+					if err = blkComplete.Block.VerifyStateRoot(dataStorage); err != nil {
+						return
+					}
+
+					if err = chain.saveSnapshot(writer, blkComplete.Block, dataStorage); err != nil {
+						return
+					}
+
 					if len(removedBlocksHeights) > 0 {
 						removedBlocksHeights = removedBlocksHeights[1:]
 					}
@@ -409,6 +418,12 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 					removeTxsInfo(writer, removedTxHashes)
 				}
 
+				if config.PRUNE {
+					if err = chain.pruneBlocks(writer, newChainData.Height, dataStorage); err != nil {
+						panic(err)
+					}
+				}
+
 				if err = chain.saveBlockchainHashmaps(dataStorage); err != nil {
 					panic(err)
 				}
@@ -457,6 +472,7 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 		update.insertedTxsList = insertedTxsList
 		update.insertedBlocks = insertedBlocks
 		update.allTransactionsChanges = allTransactionsChanges
+		update.allForgersChanges = allForgersChanges
 	}
 
 	chain.updatesQueue.updatesCn <- update
@@ -482,6 +498,7 @@ func CreateBlockchain(mempool *mempool.Mempool, txsValidator *txs_validator.TxsV
 		multicast.NewMulticastChannel[*blockchain_types.BlockchainUpdates](),
 		multicast.NewMulticastChannel[[]*blockchain_types.BlockchainTransactionUpdate](),
 		multicast.NewMulticastChannel[*data_storage.DataStorage](),
+		multicast.NewMulticastChannel[[]*blockchain_types.BlockchainForgerUpdate](),
 		make(chan *forging_block_work.ForgingWork),
 	}
 
@@ -490,6 +507,8 @@ func CreateBlockchain(mempool *mempool.Mempool, txsValidator *txs_validator.TxsV
 	chain.updatesQueue.processBlockchainUpdateMempool()
 	chain.updatesQueue.processBlockchainUpdateNotifications()
 
+	chain.initCLI()
+
 	return chain, nil
 }
 
@@ -507,10 +526,14 @@ func (chain *Blockchain) InitializeChain() (err error) {
 		}
 	}
 
+	if err = chain.buildStateTrees(); err != nil {
+		return
+	}
+
 	chainData := chain.GetChainData()
 	chainData.updateChainInfo()
 
-	return
+	return chain.resumeReindexInfo()
 }
 
 func (chain *Blockchain) Close() {
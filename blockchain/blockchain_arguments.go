@@ -0,0 +1,43 @@
+package blockchain
+
+import (
+	"errors"
+	"pandora-pay/config/globals"
+)
+
+func (chain *Blockchain) ProcessChainArguments() (err error) {
+
+	if mode := globals.Arguments["--verify-store"]; mode != nil {
+		switch mode.(string) {
+		case "check":
+			err = chain.verifyStoreAndReport(false)
+		case "rebuild":
+			err = chain.verifyStoreAndReport(true)
+		default:
+			err = errors.New("--verify-store accepts only: check, rebuild")
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--reindex-info"] == true {
+		if err = chain.ReindexInfo(); err != nil {
+			return
+		}
+	}
+
+	if filename := globals.Arguments["--chain-import"]; filename != nil {
+		if err = chain.importChainFromFile(filename.(string)); err != nil {
+			return
+		}
+	}
+
+	if filename := globals.Arguments["--chain-export"]; filename != nil {
+		if err = chain.exportChainToFile(filename.(string), 0); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,113 @@
+package blockchain
+
+import (
+	"pandora-pay/blockchain/info"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"strings"
+)
+
+//the search terms are the prefixes of the ticker, of the name and of every word of the name
+func GetAssetSearchTerms(name, ticker string) map[string]bool {
+
+	terms := make(map[string]bool)
+
+	addPrefixes := func(text string) {
+		for i := 1; i <= len(text); i++ {
+			terms[text[:i]] = true
+		}
+	}
+
+	//the spaces are normalized like in the queries
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	addPrefixes(strings.ToLower(ticker))
+	addPrefixes(name)
+	for _, word := range strings.Fields(name) {
+		addPrefixes(word)
+	}
+
+	return terms
+}
+
+func getAssetsIndexCount(reader store_db_interface.StoreDBTransactionInterface, index, term string) (uint64, error) {
+	data := reader.Get(index + "Count:" + term)
+	if data == nil {
+		return 0, nil
+	}
+	return strconv.ParseUint(string(data), 10, 64)
+}
+
+//the length of the term is written before it, so the prefix of a term never matches the keys of a longer term
+func getAssetsIndexPrefix(index, term string) string {
+	return index + ":" + strconv.Itoa(len(term)) + ":" + term + ":"
+}
+
+//every asset of a term is stored under its own key, so a write doesn't depend on the number of assets of the term
+func updateAssetsIndex(writer store_db_interface.StoreDBTransactionInterface, index, term string, hash []byte, insert bool) error {
+
+	key := getAssetsIndexPrefix(index, term) + string(hash)
+	if writer.Exists(key) == insert {
+		return nil
+	}
+
+	count, err := getAssetsIndexCount(writer, index, term)
+	if err != nil {
+		return err
+	}
+
+	if insert {
+		writer.Put(key, hash)
+		count += 1
+	} else {
+		writer.Delete(key)
+		count -= 1
+	}
+
+	if count == 0 {
+		writer.Delete(index + "Count:" + term)
+	} else {
+		writer.Put(index+"Count:"+term, []byte(strconv.FormatUint(count, 10)))
+	}
+	return nil
+}
+
+func updateAssetSearchIndex(writer store_db_interface.StoreDBTransactionInterface, hash []byte, astInfo *info.AssetInfo, insert bool) (err error) {
+
+	for term := range GetAssetSearchTerms(astInfo.Name, astInfo.Ticker) {
+		if err = updateAssetsIndex(writer, "assetsSearch", term, hash, insert); err != nil {
+			return
+		}
+	}
+
+	return updateAssetsIndex(writer, "assetsByTicker", astInfo.Ticker, hash, insert)
+}
+
+func GetAssetsSearchCount(reader store_db_interface.StoreDBTransactionInterface, term string) (uint64, error) {
+	return getAssetsIndexCount(reader, "assetsSearch", term)
+}
+
+func GetAssetsByTickerCount(reader store_db_interface.StoreDBTransactionInterface, ticker string) (uint64, error) {
+	return getAssetsIndexCount(reader, "assetsByTicker", ticker)
+}
+
+//the hashes are returned in byte order, so the results are the same on all the nodes
+func GetAssetsSearch(reader store_db_interface.StoreDBTransactionInterface, term string, start, limit uint64) ([][]byte, error) {
+
+	list := make([][]byte, 0, limit)
+	if limit == 0 {
+		return list, nil
+	}
+
+	if err := reader.Iterate(getAssetsIndexPrefix("assetsSearch", term), "", false, func(key string, value []byte) bool {
+		if start > 0 {
+			start -= 1
+			return true
+		}
+		list = append(list, value)
+		return uint64(len(list)) < limit
+	}); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
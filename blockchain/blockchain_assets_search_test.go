@@ -0,0 +1,172 @@
+package blockchain
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sort"
+	"testing"
+)
+
+func TestGetAssetSearchTerms(t *testing.T) {
+
+	terms := GetAssetSearchTerms("Pandora Pay", "PAY")
+
+	for _, term := range []string{"p", "pa", "pay", "pan", "pandora", "pandora p", "pandora pay"} {
+		assert.True(t, terms[term], term)
+	}
+	for _, term := range []string{"ay", "ora", "Pay", "pandora pay "} {
+		assert.False(t, terms[term], term)
+	}
+
+	//the spaces of the name are normalized like in the queries
+	terms = GetAssetSearchTerms(" Pandora   Pay ", "PAY")
+	assert.True(t, terms["pandora pay"])
+	assert.False(t, terms[" "])
+	assert.False(t, terms["pandora  "])
+}
+
+func TestBlockchainAssetsSearch_TermsWithSeparator(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	//the asset name regex allows other characters after the first alphanumeric ones
+	hashes := [][]byte{helpers.RandomBytes(cryptography.PublicKeyHashSize), helpers.RandomBytes(cryptography.PublicKeyHashSize)}
+	infos := []*info.AssetInfo{{Name: "ab:cd", Ticker: "ABCD"}, {Name: "ab", Ticker: "XY"}}
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		for i := range hashes {
+			if err := updateAssetSearchIndex(writer, hashes[i], infos[i], true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for _, term := range []string{"ab", "ab:", "ab:c"} {
+			count, err := GetAssetsSearchCount(reader, term)
+			assert.NoError(t, err)
+
+			list, err := GetAssetsSearch(reader, term, 0, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, int(count), len(list), term)
+		}
+
+		list, err := GetAssetsSearch(reader, "ab", 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+
+		list, err = GetAssetsSearch(reader, "ab:", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{hashes[0]}, list)
+		return nil
+	}))
+}
+
+func TestBlockchainAssetsSearch_Index(t *testing.T) {
+
+	hashes := make([][]byte, 3)
+	for i := range hashes {
+		hashes[i] = helpers.RandomBytes(cryptography.PublicKeyHashSize)
+	}
+
+	infos := []*info.AssetInfo{
+		{Name: "Pandora Pay", Ticker: "PAY"},
+		{Name: "Payment Token", Ticker: "PMT"},
+		{Name: "Other", Ticker: "PAY"},
+	}
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	update := func(i int, insert bool) {
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			return updateAssetSearchIndex(writer, hashes[i], infos[i], insert)
+		}))
+	}
+
+	search := func(term string, start, limit uint64) (count uint64, list [][]byte) {
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			if count, err = GetAssetsSearchCount(reader, term); err != nil {
+				return
+			}
+			list, err = GetAssetsSearch(reader, term, start, limit)
+			return
+		}))
+		return
+	}
+
+	sorted := func(list ...[]byte) [][]byte {
+		list = append([][]byte{}, list...)
+		sort.Slice(list, func(i, j int) bool {
+			return bytes.Compare(list[i], list[j]) < 0
+		})
+		return list
+	}
+
+	for i := range hashes {
+		update(i, true)
+	}
+	//inserting again doesn't change the index
+	update(0, true)
+
+	count, list := search("pay", 0, 10)
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, sorted(hashes...), list)
+
+	count, list = search("pa", 1, 1)
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, sorted(hashes...)[1:2], list)
+
+	count, list = search("pandora p", 0, 10)
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, [][]byte{hashes[0]}, list)
+
+	count, list = search("pay", 5, 10)
+	assert.Equal(t, uint64(3), count)
+	assert.Empty(t, list)
+
+	count, list = search("token", 0, 10)
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, [][]byte{hashes[1]}, list)
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		sameTicker, err := GetAssetsByTickerCount(reader, "PAY")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), sameTicker)
+		return nil
+	}))
+
+	update(0, false)
+	//removing again doesn't change the index
+	update(0, false)
+
+	count, list = search("pay", 0, 10)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, sorted(hashes[1], hashes[2]), list)
+
+	count, list = search("pandora", 0, 10)
+	assert.Equal(t, uint64(0), count)
+	assert.Empty(t, list)
+
+	for i := 1; i < len(hashes); i++ {
+		update(i, false)
+	}
+
+	//no key is left behind
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for _, prefix := range []string{"assetsSearch", "assetsByTicker"} {
+			assert.NoError(t, reader.Iterate(prefix, "", false, func(key string, value []byte) bool {
+				assert.Fail(t, "index key was not removed", key)
+				return true
+			}))
+		}
+		return nil
+	}))
+}
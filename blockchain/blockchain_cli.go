@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"context"
+	"os"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+)
+
+func (chain *Blockchain) exportChainToFile(filename string, start uint64) (err error) {
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	count, err := chain.ExportChain(f, start)
+	if err != nil {
+		return
+	}
+
+	gui.GUI.Info("Exported", count, "blocks successfully to:", filename)
+	return
+}
+
+func (chain *Blockchain) importChainFromFile(filename string) (err error) {
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	count, err := chain.ImportChain(f)
+	if err != nil {
+		return
+	}
+
+	gui.GUI.Info("Imported", count, "blocks successfully from:", filename)
+	return
+}
+
+func (chain *Blockchain) initCLI() {
+
+	cliExportChain := func(cmd string, ctx context.Context) (err error) {
+		start := gui.GUI.OutputReadUint64("Start height. Leave empty for genesis", true, 0, nil)
+		filename := gui.GUI.OutputReadFilename("Path to export", "chain")
+		return chain.exportChainToFile(filename, start)
+	}
+
+	cliImportChain := func(cmd string, ctx context.Context) (err error) {
+		filename := gui.GUI.OutputReadFilename("Path to import", "chain")
+		return chain.importChainFromFile(filename)
+	}
+
+	cliVerifyStore := func(cmd string, ctx context.Context) (err error) {
+		rebuild := false
+		if config.SEED_WALLET_NODES_INFO {
+			rebuild = gui.GUI.OutputReadBool("Rebuild the indexes afterwards? y/n. Leave empty for no", true, false)
+		}
+		return chain.verifyStoreAndReport(rebuild)
+	}
+
+	cliReindexInfo := func(cmd string, ctx context.Context) (err error) {
+		return chain.ReindexInfo()
+	}
+
+	gui.GUI.CommandDefineCallback("Export Chain", cliExportChain, true)
+	gui.GUI.CommandDefineCallback("Import Chain", cliImportChain, true)
+	gui.GUI.CommandDefineCallback("Verify Store", cliVerifyStore, true)
+	gui.GUI.CommandDefineCallback("Reindex Info", cliReindexInfo, config.SEED_WALLET_NODES_INFO)
+}
@@ -0,0 +1,298 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/vmihailenco/msgpack/v5"
+	"hash"
+	"io"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/genesis"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+var chainExportMagic = []byte("PANDORA-CHAIN")
+
+//the exported file contains the header, the serialized blocks complete and the sha256 checksum of all the previous bytes
+type ChainExportHeader struct {
+	Version     uint64
+	Network     uint64
+	GenesisHash []byte
+	Start       uint64
+	Count       uint64
+}
+
+func (header *ChainExportHeader) Serialize(w *helpers.BufferWriter) {
+	w.Write(chainExportMagic)
+	w.WriteUvarint(header.Version)
+	w.WriteUvarint(header.Network)
+	w.WriteVariableBytes(header.GenesisHash)
+	w.WriteUvarint(header.Start)
+	w.WriteUvarint(header.Count)
+}
+
+//hashes all the bytes that were read
+type chainExportReader struct {
+	r *bufio.Reader
+	h hash.Hash
+}
+
+func (reader *chainExportReader) ReadByte() (byte, error) {
+	b, err := reader.r.ReadByte()
+	if err == nil {
+		reader.h.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (reader *chainExportReader) readBytes(size uint64) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader.r, data); err != nil {
+		return nil, err
+	}
+	reader.h.Write(data)
+	return data, nil
+}
+
+func (reader *chainExportReader) readVariableBytes(limit uint64) ([]byte, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if size > limit {
+		return nil, errors.New("Size is too big")
+	}
+	return reader.readBytes(size)
+}
+
+func (reader *chainExportReader) readHeader() (header *ChainExportHeader, err error) {
+
+	magic, err := reader.readBytes(uint64(len(chainExportMagic)))
+	if err != nil {
+		return
+	}
+	if !bytes.Equal(magic, chainExportMagic) {
+		return nil, errors.New("File is not a chain export")
+	}
+
+	header = &ChainExportHeader{}
+	if header.Version, err = binary.ReadUvarint(reader); err != nil {
+		return
+	}
+	if header.Version != config.CHAIN_EXPORT_VERSION {
+		return nil, errors.New("Chain export version is not supported")
+	}
+	if header.Network, err = binary.ReadUvarint(reader); err != nil {
+		return
+	}
+	if header.Network != config.NETWORK_SELECTED {
+		return nil, errors.New("Chain export is for a different network")
+	}
+	if header.GenesisHash, err = reader.readVariableBytes(32); err != nil {
+		return
+	}
+	if !bytes.Equal(header.GenesisHash, genesis.GenesisData.Hash) {
+		return nil, errors.New("Chain export genesis is not matching")
+	}
+	if header.Start, err = binary.ReadUvarint(reader); err != nil {
+		return
+	}
+	if header.Count, err = binary.ReadUvarint(reader); err != nil {
+		return
+	}
+
+	return
+}
+
+//reads all the blocks and verifies the checksum at the end
+func readChainExport(r io.Reader, callback func(header *ChainExportHeader, data []byte) error) (header *ChainExportHeader, err error) {
+
+	reader := &chainExportReader{bufio.NewReader(r), sha256.New()}
+
+	if header, err = reader.readHeader(); err != nil {
+		return
+	}
+
+	for i := uint64(0); i < header.Count; i++ {
+
+		var data []byte
+		if data, err = reader.readVariableBytes(config.BLOCK_MAX_SIZE); err != nil {
+			return
+		}
+
+		if callback != nil {
+			if err = callback(header, data); err != nil {
+				return
+			}
+		}
+	}
+
+	checksum := make([]byte, sha256.Size)
+	if _, err = io.ReadFull(reader.r, checksum); err != nil {
+		return
+	}
+	if !bytes.Equal(checksum, reader.h.Sum(nil)) {
+		return nil, errors.New("Chain export checksum is invalid")
+	}
+
+	return
+}
+
+func (chain *Blockchain) loadBlockCompleteSerialized(reader store_db_interface.StoreDBTransactionInterface, height uint64) ([]byte, error) {
+
+	hash, err := chain.LoadBlockHash(reader, height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockData := reader.Get("block_ByHash" + string(hash))
+	if blockData == nil {
+		return nil, errors.New("Block was not found")
+	}
+
+	txHashes := [][]byte{}
+	if err = msgpack.Unmarshal(reader.Get("blockTxs"+strconv.FormatUint(height, 10)), &txHashes); err != nil {
+		return nil, err
+	}
+
+	//same as BlockComplete.AdvancedSerialization
+	w := helpers.NewBufferWriter()
+	w.Write(blockData)
+	w.WriteUvarint(uint64(len(txHashes)))
+	for _, txHash := range txHashes {
+		tx := reader.Get("tx:" + string(txHash))
+		if tx == nil {
+			return nil, errors.New("Tx was not found")
+		}
+		w.Write(tx)
+	}
+
+	return w.Bytes(), nil
+}
+
+//exports all the blocks starting with the given height
+func (chain *Blockchain) ExportChain(w io.Writer, start uint64) (count uint64, err error) {
+
+	h := sha256.New()
+	writer := bufio.NewWriter(io.MultiWriter(w, h))
+
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+		if start >= chainHeight {
+			return errors.New("Start height is invalid")
+		}
+
+		if prunedHeight := chain.LoadPrunedHeight(reader); start < prunedHeight {
+			return fmt.Errorf("Blocks before %d were pruned", prunedHeight)
+		}
+
+		header := &ChainExportHeader{config.CHAIN_EXPORT_VERSION, config.NETWORK_SELECTED, genesis.GenesisData.Hash, start, chainHeight - start}
+
+		buf := helpers.NewBufferWriter()
+		header.Serialize(buf)
+		if _, err = writer.Write(buf.Bytes()); err != nil {
+			return
+		}
+
+		for height := start; height < chainHeight; height++ {
+
+			var data []byte
+			if data, err = chain.loadBlockCompleteSerialized(reader, height); err != nil {
+				return
+			}
+
+			buf = helpers.NewBufferWriter()
+			buf.WriteVariableBytes(data)
+			if _, err = writer.Write(buf.Bytes()); err != nil {
+				return
+			}
+		}
+
+		count = header.Count
+		return
+	}); err != nil {
+		return
+	}
+
+	if err = writer.Flush(); err != nil {
+		return
+	}
+
+	_, err = w.Write(h.Sum(nil))
+	return
+}
+
+//the file is read twice. First to verify the checksum and afterwards to include the blocks
+func (chain *Blockchain) ImportChain(r io.ReadSeeker) (count uint64, err error) {
+
+	header, err := readChainExport(r, nil)
+	if err != nil {
+		return
+	}
+
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+
+	gui.GUI.Info("Importing chain " + strconv.FormatUint(header.Start, 10) + " ... " + strconv.FormatUint(header.Start+header.Count, 10))
+
+	batch := make([]*block_complete.BlockComplete, 0)
+	height := header.Start
+
+	includeBatch := func() (err error) {
+		if len(batch) > 0 {
+			if _, err = chain.AddBlocks(batch, false, advanced_connection_types.UUID_ALL); err != nil {
+				return
+			}
+			count += uint64(len(batch))
+			batch = make([]*block_complete.BlockComplete, 0)
+		}
+		return
+	}
+
+	if _, err = readChainExport(r, func(header *ChainExportHeader, data []byte) (err error) {
+
+		blkComplete := block_complete.CreateEmptyBlockComplete()
+		if err = blkComplete.Deserialize(helpers.NewBufferReader(data)); err != nil {
+			return
+		}
+		if err = blkComplete.BloomAll(); err != nil {
+			return
+		}
+
+		if blkComplete.Block.Height != height {
+			return errors.New("Block height is not matching")
+		}
+		height += 1
+
+		//blocks already included are skipped
+		if blkComplete.Block.Height < chain.GetChainData().Height {
+			if hash, err := chain.OpenLoadBlockHash(blkComplete.Block.Height); err == nil && bytes.Equal(hash, blkComplete.Block.Bloom.Hash) {
+				return nil
+			}
+		}
+
+		batch = append(batch, blkComplete)
+		if len(batch) == config.CHAIN_IMPORT_BATCH_SIZE {
+			return includeBatch()
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	err = includeBatch()
+	return
+}
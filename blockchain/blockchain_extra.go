@@ -144,6 +144,8 @@ func (chain *Blockchain) initializeNewChain(chainData *BlockchainData, dataStora
 		config_coins.NATIVE_ASSET_IDENTIFICATION,
 		config_coins.NATIVE_ASSET_DESCRIPTION,
 		nil,
+		false,
+		false,
 	}
 
 	if err = dataStorage.Asts.CreateAsset(config_coins.NATIVE_ASSET_FULL, ast); err != nil {
@@ -178,6 +180,9 @@ func (chain *Blockchain) init() (*BlockchainData, error) {
 			if err = saveAssetsInfo(dataStorage.Asts); err != nil {
 				return
 			}
+			if err = saveAssetsHolders(writer, dataStorage.AccsCollection); err != nil {
+				return
+			}
 		}
 
 		return
@@ -224,7 +229,7 @@ func (chain *Blockchain) createNextBlockForForging(chainData *BlockchainData, ne
 		} else {
 			blk = &block.Block{
 				BlockHeader: &block.BlockHeader{
-					Version: 0,
+					Version: block.GetBlockVersion(chainData.Height),
 					Height:  chainData.Height,
 				},
 				MerkleHash:     cryptography.SHA3([]byte{}),
@@ -234,6 +239,9 @@ func (chain *Blockchain) createNextBlockForForging(chainData *BlockchainData, ne
 			}
 		}
 
+		if blk.HasStateRoot() {
+			blk.StateRoot = make([]byte, cryptography.HashSize)
+		}
 		blk.Forger = make([]byte, cryptography.PublicKeyHashSize)
 		blk.DelegatedStakePublicKey = make([]byte, cryptography.PublicKeySize)
 		blk.Signature = make([]byte, cryptography.SignatureSize)
@@ -47,6 +47,7 @@ func removeBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterfa
 
 			count -= 1
 			writer.Delete("addrTx:" + string(key) + ":" + strconv.FormatUint(count, 10))
+			writer.Delete("addrTxInfo:" + string(key) + ":" + strconv.FormatUint(count, 10))
 			if count == 0 {
 				writer.Delete("addrTxsCount:" + string(key))
 			} else {
@@ -79,26 +80,26 @@ func saveAssetsInfo(asts *assets.Assets) (err error) {
 
 	for k, v := range asts.Committed {
 
+		//the previous info is removed from the search index, including when the asset was reverted
+		var oldInfo *info.AssetInfo
+		if data := asts.Tx.Get("assetInfo_ByHash:" + k); data != nil {
+			oldInfo = &info.AssetInfo{}
+			if err = msgpack.Unmarshal(data, oldInfo); err != nil {
+				return
+			}
+		}
+
 		if v.Stored == "del" {
 			asts.Tx.Delete("assetInfo_ByHash:" + k)
-		} else if v.Stored == "update" {
-
-			ast := v.Element.(*asset.Asset)
-			astInfo := &info.AssetInfo{
-				ast.Version,
-				ast.Name,
-				ast.Ticker,
-				ast.Identification,
-				ast.DecimalSeparator,
-				ast.Description[:generics.Min(100, len(ast.Description))],
-				[]byte(k),
+			if oldInfo != nil {
+				if err = updateAssetSearchIndex(asts.Tx, []byte(k), oldInfo, false); err != nil {
+					return
+				}
 			}
-			var data []byte
-			if data, err = msgpack.Marshal(astInfo); err != nil {
+		} else if v.Stored == "update" {
+			if err = saveAssetInfo(asts.Tx, k, v.Element.(*asset.Asset), oldInfo); err != nil {
 				return
 			}
-
-			asts.Tx.Put("assetInfo_ByHash:"+k, data)
 		}
 
 	}
@@ -106,6 +107,43 @@ func saveAssetsInfo(asts *assets.Assets) (err error) {
 	return
 }
 
+//writes the info of the asset and updates the search index when the name or the ticker changed
+func saveAssetInfo(writer store_db_interface.StoreDBTransactionInterface, k string, ast *asset.Asset, oldInfo *info.AssetInfo) (err error) {
+
+	astInfo := &info.AssetInfo{
+		ast.Version,
+		ast.Name,
+		ast.Ticker,
+		ast.Identification,
+		ast.DecimalSeparator,
+		ast.Description[:generics.Min(100, len(ast.Description))],
+		[]byte(k),
+		ast.MaxSupply,
+		ast.Supply,
+		ast.Paused,
+		ast.Frozen,
+	}
+	var data []byte
+	if data, err = msgpack.Marshal(astInfo); err != nil {
+		return
+	}
+
+	writer.Put("assetInfo_ByHash:"+k, data)
+
+	if oldInfo == nil || oldInfo.Name != astInfo.Name || oldInfo.Ticker != astInfo.Ticker {
+		if oldInfo != nil {
+			if err = updateAssetSearchIndex(writer, []byte(k), oldInfo, false); err != nil {
+				return
+			}
+		}
+		if err = updateAssetSearchIndex(writer, []byte(k), astInfo, true); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func saveBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, transactionsCount uint64, localTransactionChanges []*blockchain_types.BlockchainTransactionUpdate) (err error) {
 
 	var fees uint64
@@ -184,7 +222,16 @@ func saveBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterface
 				key, count,
 			}
 
+			var accountTx *info.AccountTx
+			if accountTx, err = info.CreateAccountTx(tx, key, blkComplete.Height, blkComplete.Timestamp); err != nil {
+				return
+			}
+			if buffer, err = msgpack.Marshal(accountTx); err != nil {
+				return
+			}
+
 			writer.Put("addrTx:"+keyStr+":"+strconv.FormatUint(count, 10), tx.Bloom.Hash)
+			writer.Put("addrTxInfo:"+keyStr+":"+strconv.FormatUint(count, 10), buffer)
 			writer.Put("addrTxsCount:"+keyStr, []byte(strconv.FormatUint(count+1, 10)))
 		}
 
@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+)
+
+func createExtraInfoTestBlock(height uint64, txs []*transaction.Transaction) *block_complete.BlockComplete {
+	return &block_complete.BlockComplete{
+		Block: &block.Block{
+			BlockHeader: &block.BlockHeader{Version: block.GetBlockVersion(height), Height: height},
+			Timestamp:   1000 + height,
+			Bloom:       &block.BlockBloom{Hash: cryptography.SHA3(helpers.RandomBytes(32))},
+		},
+		Txs:              txs,
+		BloomBlkComplete: &block_complete.BlockCompleteBloom{Size: 100},
+	}
+}
+
+func TestBlockchainExtraInfo_AccountTxs(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.NoError(t, err)
+
+	sender := privateKey.GeneratePublicKeyHash()
+	receivers := [][]byte{
+		helpers.RandomBytes(cryptography.PublicKeyHashSize),
+		helpers.RandomBytes(cryptography.PublicKeyHashSize),
+	}
+
+	blocks := make([]*block_complete.BlockComplete, len(receivers))
+	for i, receiver := range receivers {
+		tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+			nil,
+			&wizard.WizardTransactionData{},
+			&wizard.WizardTransactionFee{},
+			uint64(i),
+			[]*wizard.WizardTxSimpleTransferVin{{privateKey.Key, 10, config_coins.NATIVE_ASSET_FULL, nil}},
+			[]*wizard.WizardTxSimpleTransferVout{{receiver, 10, config_coins.NATIVE_ASSET_FULL}},
+		}, true, func(string) {})
+		assert.NoError(t, err)
+		blocks[i] = createExtraInfoTestBlock(uint64(i+1), []*transaction.Transaction{tx})
+	}
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	getCount := func(reader store_db_interface.StoreDBTransactionInterface, key []byte) string {
+		return string(reader.Get("addrTxsCount:" + string(key)))
+	}
+
+	getAccountTx := func(reader store_db_interface.StoreDBTransactionInterface, key []byte, index string) *info.AccountTx {
+		data := reader.Get("addrTxInfo:" + string(key) + ":" + index)
+		if data == nil {
+			return nil
+		}
+		accountTx := &info.AccountTx{}
+		assert.NoError(t, msgpack.Unmarshal(data, accountTx))
+		return accountTx
+	}
+
+	save := func(blkComplete *block_complete.BlockComplete, transactionsCount uint64) {
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			return saveBlockCompleteInfo(writer, blkComplete, transactionsCount, []*blockchain_types.BlockchainTransactionUpdate{{}})
+		}))
+	}
+
+	remove := func(blkComplete *block_complete.BlockComplete) {
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			return removeBlockCompleteInfo(writer, blkComplete.Block.Bloom.Hash, [][]byte{blkComplete.Txs[0].Bloom.Hash}, []*blockchain_types.BlockchainTransactionUpdate{{}})
+		}))
+	}
+
+	save(blocks[0], 0)
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		assert.Equal(t, "1", getCount(reader, sender))
+		assert.Equal(t, "1", getCount(reader, receivers[0]))
+		assert.Equal(t, "", getCount(reader, receivers[1]))
+
+		accountTx := getAccountTx(reader, sender, "0")
+		assert.NotNil(t, accountTx)
+		assert.Equal(t, blocks[0].Txs[0].Bloom.Hash, accountTx.Hash)
+		assert.Equal(t, uint64(1), accountTx.BlkHeight)
+		assert.True(t, accountTx.Matches(config_coins.NATIVE_ASSET_FULL, info.ACCOUNT_TX_OUTGOING))
+		assert.False(t, accountTx.Matches(config_coins.NATIVE_ASSET_FULL, info.ACCOUNT_TX_INCOMING))
+
+		accountTx = getAccountTx(reader, receivers[0], "0")
+		assert.NotNil(t, accountTx)
+		assert.True(t, accountTx.Matches(nil, info.ACCOUNT_TX_INCOMING))
+		assert.False(t, accountTx.Matches(nil, info.ACCOUNT_TX_OUTGOING))
+		return nil
+	}))
+
+	save(blocks[1], 1)
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, "2", getCount(reader, sender))
+		assert.Equal(t, "1", getCount(reader, receivers[0]))
+		assert.Equal(t, "1", getCount(reader, receivers[1]))
+
+		accountTx := getAccountTx(reader, sender, "1")
+		assert.NotNil(t, accountTx)
+		assert.Equal(t, blocks[1].Txs[0].Bloom.Hash, accountTx.Hash)
+		assert.NotNil(t, getAccountTx(reader, receivers[1], "0"))
+		return nil
+	}))
+
+	remove(blocks[1])
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, "1", getCount(reader, sender))
+		assert.Equal(t, "1", getCount(reader, receivers[0]))
+		assert.Equal(t, "", getCount(reader, receivers[1]))
+
+		assert.NotNil(t, getAccountTx(reader, sender, "0"))
+		assert.Nil(t, getAccountTx(reader, sender, "1"))
+		assert.Nil(t, getAccountTx(reader, receivers[1], "0"))
+		assert.Nil(t, reader.Get("addrTx:"+string(sender)+":1"))
+		return nil
+	}))
+
+	remove(blocks[0])
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for _, key := range [][]byte{sender, receivers[0], receivers[1]} {
+			assert.Equal(t, "", getCount(reader, key))
+			assert.Nil(t, getAccountTx(reader, key, "0"))
+			assert.Nil(t, reader.Get("addrTx:"+string(key)+":0"))
+		}
+		assert.Nil(t, reader.Get("txKeys:"+string(blocks[0].Txs[0].Bloom.Hash)))
+		return nil
+	}))
+}
@@ -0,0 +1,102 @@
+package blockchain
+
+import (
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//the blocks of a forger are appended in the order of the heights, so the last one is removed when the block is reverted
+func saveForgerBlock(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete) (update *blockchain_types.BlockchainForgerUpdate, err error) {
+
+	forger := blkComplete.Block.Forger
+
+	var count uint64
+	if count, err = info.LoadForgerBlocksCount(writer, forger); err != nil {
+		return
+	}
+
+	var prev *info.ForgerBlock
+	if count > 0 {
+		if prev, err = info.LoadForgerBlock(writer, forger, count-1); err != nil {
+			return
+		}
+	}
+
+	var forgerBlock *info.ForgerBlock
+	if forgerBlock, err = info.CreateForgerBlock(blkComplete, prev); err != nil {
+		return
+	}
+
+	var data []byte
+	if data, err = msgpack.Marshal(forgerBlock); err != nil {
+		return
+	}
+
+	writer.Put("forger_ByHeight"+strconv.FormatUint(blkComplete.Block.Height, 10), forger)
+	writer.Put("forgerBlock:"+string(forger)+":"+strconv.FormatUint(count, 10), data)
+	writer.Put("forgerBlocksCount:"+string(forger), []byte(strconv.FormatUint(count+1, 10)))
+
+	return &blockchain_types.BlockchainForgerUpdate{
+		forger,
+		forgerBlock.Hash,
+		true,
+		forgerBlock.BlkHeight,
+		count + 1,
+		forgerBlock.Reward,
+		forgerBlock.Fees,
+		forgerBlock.Commission,
+	}, nil
+}
+
+func removeForgerBlock(writer store_db_interface.StoreDBTransactionInterface, blockHeight uint64) (update *blockchain_types.BlockchainForgerUpdate, err error) {
+
+	blockHeightStr := strconv.FormatUint(blockHeight, 10)
+
+	//blocks stored before the forgers stats were indexed
+	forger := writer.Get("forger_ByHeight" + blockHeightStr)
+	if forger == nil {
+		return nil, nil
+	}
+	forger = append([]byte{}, forger...)
+
+	var count uint64
+	if count, err = info.LoadForgerBlocksCount(writer, forger); err != nil {
+		return
+	}
+	if count == 0 {
+		return nil, errors.New("forgerBlocksCount: was empty")
+	}
+
+	var forgerBlock *info.ForgerBlock
+	if forgerBlock, err = info.LoadForgerBlock(writer, forger, count-1); err != nil {
+		return
+	}
+	if forgerBlock.BlkHeight != blockHeight {
+		return nil, errors.New("Forger last block is not matching the removed block")
+	}
+
+	count -= 1
+	writer.Delete("forger_ByHeight" + blockHeightStr)
+	writer.Delete("forgerBlock:" + string(forger) + ":" + strconv.FormatUint(count, 10))
+	if count == 0 {
+		writer.Delete("forgerBlocksCount:" + string(forger))
+	} else {
+		writer.Put("forgerBlocksCount:"+string(forger), []byte(strconv.FormatUint(count, 10)))
+	}
+
+	return &blockchain_types.BlockchainForgerUpdate{
+		forger,
+		forgerBlock.Hash,
+		false,
+		blockHeight,
+		count,
+		forgerBlock.Reward,
+		forgerBlock.Fees,
+		forgerBlock.Commission,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_reward"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"strconv"
+	"testing"
+)
+
+func TestBlockchainForgers_SaveRemove(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.NoError(t, err)
+
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		Data:  &wizard.WizardTransactionData{},
+		Fee:   &wizard.WizardTransactionFee{Fixed: 30},
+		Nonce: 0,
+		Vin:   []*wizard.WizardTxSimpleTransferVin{{Key: privateKey.Key, Amount: 10, Asset: config_coins.NATIVE_ASSET_FULL}},
+		Vout:  []*wizard.WizardTxSimpleTransferVout{{PublicKeyHash: helpers.RandomBytes(cryptography.PublicKeyHashSize), Amount: 10, Asset: config_coins.NATIVE_ASSET_FULL}},
+	}, true, func(string) {})
+	assert.NoError(t, err)
+
+	fee, err := tx.ComputeFee()
+	assert.NoError(t, err)
+	assert.Greater(t, fee, uint64(0))
+
+	forger := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+	rewardCollector := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+
+	//the second block is forged by a delegated stake with a fee of 25%, so the commission is taken from the reward and the fees
+	blocks := []*block_complete.BlockComplete{
+		createExtraInfoTestBlock(1, []*transaction.Transaction{}),
+		createExtraInfoTestBlock(2, []*transaction.Transaction{tx}),
+		createExtraInfoTestBlock(3, []*transaction.Transaction{}),
+	}
+	for _, blkComplete := range blocks {
+		blkComplete.Block.Forger = forger
+		blkComplete.Block.StakingAmount = config_stake.GetRequiredStake(blkComplete.Block.Height)
+	}
+	blocks[1].Block.DelegatedStakeFee = config_stake.DELEGATING_STAKING_FEE_MAX_VALUE / 4
+	blocks[1].Block.RewardCollector = rewardCollector
+
+	//the third block is forged by another forger
+	other := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+	blocks[2].Block.Forger = other
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	for _, blkComplete := range blocks {
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			update, err := saveForgerBlock(writer, blkComplete)
+			assert.NoError(t, err)
+			assert.True(t, update.Inserted)
+			assert.Equal(t, blkComplete.Block.Height, update.BlockHeight)
+			return err
+		}))
+	}
+
+	reward1, reward2 := config_reward.GetRewardAt(1), config_reward.GetRewardAt(2)
+	commission := (reward2 + fee) / 4
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		count, err := info.LoadForgerBlocksCount(reader, forger)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), count)
+
+		count, err = info.LoadForgerBlocksCount(reader, other)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), count)
+
+		first, err := info.LoadForgerBlock(reader, forger, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[0].Block.Bloom.Hash, first.Hash)
+		assert.Equal(t, reward1, first.Reward)
+		assert.Equal(t, uint64(0), first.Commission)
+		assert.Nil(t, first.RewardCollector)
+
+		second, err := info.LoadForgerBlock(reader, forger, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), second.BlkHeight)
+		assert.Equal(t, fee, second.Fees)
+		assert.Equal(t, commission, second.Commission)
+		assert.Equal(t, blocks[1].Block.DelegatedStakeFee, second.DelegatedStakeFee)
+		assert.Equal(t, rewardCollector, second.RewardCollector)
+
+		//the totals are cumulative
+		assert.Equal(t, reward1+reward2, second.TotalReward)
+		assert.Equal(t, fee, second.TotalFees)
+		assert.Equal(t, commission, second.TotalCommission)
+
+		_, err = info.LoadForgerBlock(reader, forger, 2)
+		assert.Error(t, err)
+
+		assert.Equal(t, forger, reader.Get("forger_ByHeight2"))
+		assert.Equal(t, other, reader.Get("forger_ByHeight3"))
+		return nil
+	}))
+
+	//the blocks are removed in the reverse order on a rollback
+	for i := len(blocks) - 1; i >= 0; i-- {
+		blkComplete := blocks[i]
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+
+			//only the last block of the forger can be removed
+			if i == 1 {
+				_, err := removeForgerBlock(writer, 1)
+				assert.EqualError(t, err, "Forger last block is not matching the removed block")
+			}
+
+			update, err := removeForgerBlock(writer, blkComplete.Block.Height)
+			assert.NoError(t, err)
+			assert.False(t, update.Inserted)
+			assert.Equal(t, blkComplete.Block.Forger, update.Forger)
+			return err
+		}))
+
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			assert.Nil(t, reader.Get("forger_ByHeight"+strconv.FormatUint(blkComplete.Block.Height, 10)))
+
+			count, err := info.LoadForgerBlocksCount(reader, forger)
+			assert.NoError(t, err)
+			assert.Equal(t, []uint64{0, 1, 2}[i], count)
+			return nil
+		}))
+	}
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for _, key := range []string{"forgerBlocksCount:" + string(forger), "forgerBlocksCount:" + string(other), "forgerBlock:" + string(forger) + ":0", "forgerBlock:" + string(other) + ":0"} {
+			assert.Nil(t, reader.Get(key))
+		}
+		return nil
+	}))
+
+	//blocks stored before the forgers stats were indexed are skipped
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		update, err := removeForgerBlock(writer, 1)
+		assert.Nil(t, update)
+		return err
+	}))
+}
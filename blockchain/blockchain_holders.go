@@ -0,0 +1,61 @@
+package blockchain
+
+import (
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/store/min_max_heap"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+//the holders of an asset are the accounts with a positive balance sorted by balance
+func NewAssetHolders(tx store_db_interface.StoreDBTransactionInterface, assetId []byte) *min_max_heap.HeapStoreHashMap {
+	return min_max_heap.NewMaxHeapStoreHashMap(tx, "holders:"+string(assetId))
+}
+
+func saveAssetsHolders(writer store_db_interface.StoreDBTransactionInterface, collection *accounts.AccountsCollection) (err error) {
+
+	for assetId, accs := range collection.GetAllMaps() {
+
+		var holders *min_max_heap.HeapStoreHashMap
+
+		for k, v := range accs.HashMap.Committed {
+
+			if v.Stored != "update" && v.Stored != "del" {
+				continue
+			}
+
+			if holders == nil {
+				holders = NewAssetHolders(writer, []byte(assetId))
+			}
+
+			var exists bool
+			if exists, err = holders.DictMap.Exists(k); err != nil {
+				return
+			}
+			if exists {
+				if err = holders.DeleteByKey([]byte(k)); err != nil {
+					return
+				}
+			}
+
+			if v.Stored == "update" {
+				if balance := v.Element.(*account.Account).Balance; balance > 0 {
+					if err = holders.Insert(float64(balance), []byte(k)); err != nil {
+						return
+					}
+				}
+			}
+		}
+
+		if holders != nil {
+			if err = holders.HashMap.CommitChanges(); err != nil {
+				return
+			}
+			if err = holders.DictMap.CommitChanges(); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
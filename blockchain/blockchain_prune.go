@@ -0,0 +1,106 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//the blocks below the returned height have no txs and transitions stored. The headers are kept
+func (chain *Blockchain) LoadPrunedHeight(reader store_db_interface.StoreDBTransactionInterface) uint64 {
+
+	height := chain.loadSnapshotHeight(reader)
+
+	if data := reader.Get("chainPrunedHeight"); data != nil {
+		if prunedHeight, _ := binary.Uvarint(data); prunedHeight > height {
+			height = prunedHeight
+		}
+	}
+
+	return height
+}
+
+func (chain *Blockchain) OpenLoadPrunedHeight() (height uint64, errFinal error) {
+	errFinal = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		height = chain.LoadPrunedHeight(reader)
+		return
+	})
+	return
+}
+
+//a reorg can't remove the blocks starting with the given height when their data is missing
+func (chain *Blockchain) checkBlocksRemovable(reader store_db_interface.StoreDBTransactionInterface, height uint64) error {
+
+	if height < chain.loadSnapshotHeight(reader) {
+		return errors.New("Blocks before the snapshot can't be removed")
+	}
+
+	if height < chain.LoadPrunedHeight(reader) {
+		return errors.New("Pruned blocks can't be removed")
+	}
+
+	return nil
+}
+
+func (chain *Blockchain) pruneBlock(writer store_db_interface.StoreDBTransactionInterface, blockHeight uint64, dataStorage *data_storage.DataStorage) error {
+
+	blockHeightStr := strconv.FormatUint(blockHeight, 10)
+
+	if err := dataStorage.DeleteTransitionalChangesFromStore(blockHeightStr); err != nil {
+		return err
+	}
+
+	data := writer.Get("blockTxs" + blockHeightStr)
+	if data == nil {
+		return errors.New("blockTxs was not found")
+	}
+
+	txHashes := [][]byte{}
+	if err := msgpack.Unmarshal(data, &txHashes); err != nil {
+		return err
+	}
+
+	//txHash: and txBlock: are kept to know that the tx was included
+	for _, txHash := range txHashes {
+		writer.Delete("tx:" + string(txHash))
+	}
+
+	writer.Delete("blockTxs" + blockHeightStr)
+
+	return nil
+}
+
+//deletes the data of the blocks older than the last config.PRUNE_BLOCKS blocks
+func (chain *Blockchain) pruneBlocks(writer store_db_interface.StoreDBTransactionInterface, chainHeight uint64, dataStorage *data_storage.DataStorage) error {
+
+	if chainHeight <= config.PRUNE_BLOCKS {
+		return nil
+	}
+
+	start := chain.LoadPrunedHeight(writer)
+	end := chainHeight - config.PRUNE_BLOCKS
+	if end > start+config.PRUNE_MAX_BLOCKS_PER_UPDATE {
+		end = start + config.PRUNE_MAX_BLOCKS_PER_UPDATE
+	}
+
+	if start >= end {
+		return nil
+	}
+
+	for height := start; height < end; height++ {
+		if err := chain.pruneBlock(writer, height, dataStorage); err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, end)
+	writer.Put("chainPrunedHeight", buf[:n])
+
+	return nil
+}
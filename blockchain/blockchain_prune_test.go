@@ -0,0 +1,150 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"strconv"
+	"testing"
+)
+
+func TestBlockchain_PruneBlocks(t *testing.T) {
+
+	defer func(pruneBlocks uint64, seed bool) {
+		config.PRUNE_BLOCKS = pruneBlocks
+		config.SEED_WALLET_NODES_INFO = seed
+	}(config.PRUNE_BLOCKS, config.SEED_WALLET_NODES_INFO)
+	config.PRUNE_BLOCKS = 5
+	config.SEED_WALLET_NODES_INFO = false
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	chain := &Blockchain{}
+
+	//more blocks than a db tx can prune
+	blocksCount := config.PRUNE_MAX_BLOCKS_PER_UPDATE + 10
+	plainAcc := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+
+	blocks := make([]*block_complete.BlockComplete, blocksCount)
+	for height := uint64(0); height < blocksCount; height++ {
+
+		tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+			nil,
+			&wizard.WizardTransactionData{},
+			&wizard.WizardTransactionFee{},
+			height,
+			[]*wizard.WizardTxSimpleTransferVin{{privateKey.Key, 10, config_coins.NATIVE_ASSET_FULL, nil}},
+			[]*wizard.WizardTxSimpleTransferVout{{helpers.RandomBytes(cryptography.PublicKeyHashSize), 10, config_coins.NATIVE_ASSET_FULL}},
+		}, true, func(string) {})
+		assert.NoError(t, err)
+
+		blocks[height] = createExtraInfoTestBlock(height, []*transaction.Transaction{tx})
+
+		//every block changes the plain account, so it stores the transitions to be reverted
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			dataStorage := data_storage.NewDataStorage(writer)
+
+			var acc *plain_account.PlainAccount
+			if acc, err = dataStorage.GetOrCreatePlainAccount(plainAcc); err != nil {
+				return
+			}
+			if err = acc.IncrementNonce(true); err != nil {
+				return
+			}
+			if err = dataStorage.PlainAccs.Update(string(plainAcc), acc); err != nil {
+				return
+			}
+
+			_, err = chain.saveBlockComplete(writer, blocks[height], height, map[string][]byte{}, nil, dataStorage)
+			return
+		}))
+	}
+
+	prune := func(chainHeight uint64) {
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			return chain.pruneBlocks(writer, chainHeight, data_storage.NewDataStorage(writer))
+		}))
+	}
+
+	getPrunedHeight := func() (height uint64) {
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			height = chain.LoadPrunedHeight(reader)
+			return nil
+		}))
+		return
+	}
+
+	isPruned := func(height uint64) (pruned bool) {
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+			heightStr := strconv.FormatUint(height, 10)
+			hash := blocks[height].Block.Bloom.Hash
+			txHash := string(blocks[height].Txs[0].Bloom.Hash)
+
+			//the headers and the inclusion of the txs are kept
+			assert.Equal(t, hash, reader.Get("blockHash_ByHeight"+heightStr))
+			assert.Equal(t, heightStr, string(reader.Get("blockHeight_ByHash"+string(hash))))
+			assert.True(t, reader.Exists("block_ByHash"+string(hash)))
+			assert.True(t, reader.Exists("txHash:"+txHash))
+			txBlock, _ := binary.Uvarint(reader.Get("txBlock:" + txHash))
+			assert.Equal(t, height, txBlock)
+
+			pruned = !reader.Exists("blockTxs" + heightStr)
+			assert.Equal(t, pruned, !reader.Exists("tx:"+txHash))
+			assert.Equal(t, pruned, !reader.Exists("plainAccs:transitions:"+heightStr))
+			assert.Equal(t, pruned, !reader.Exists("dataStorage:transitionsCollectionsKeys:"+heightStr))
+			return nil
+		}))
+		return
+	}
+
+	//the chain is not longer than the blocks kept
+	prune(config.PRUNE_BLOCKS)
+	assert.Equal(t, uint64(0), getPrunedHeight())
+	assert.False(t, isPruned(0))
+
+	//a db tx prunes at most PRUNE_MAX_BLOCKS_PER_UPDATE blocks
+	prune(blocksCount)
+	assert.Equal(t, config.PRUNE_MAX_BLOCKS_PER_UPDATE, getPrunedHeight())
+	assert.True(t, isPruned(0))
+	assert.True(t, isPruned(config.PRUNE_MAX_BLOCKS_PER_UPDATE-1))
+	assert.False(t, isPruned(config.PRUNE_MAX_BLOCKS_PER_UPDATE))
+
+	//the next db tx continues up to the last PRUNE_BLOCKS blocks
+	prune(blocksCount)
+	assert.Equal(t, blocksCount-config.PRUNE_BLOCKS, getPrunedHeight())
+	assert.True(t, isPruned(blocksCount-config.PRUNE_BLOCKS-1))
+	for height := blocksCount - config.PRUNE_BLOCKS; height < blocksCount; height++ {
+		assert.False(t, isPruned(height))
+	}
+
+	prune(blocksCount)
+	assert.Equal(t, blocksCount-config.PRUNE_BLOCKS, getPrunedHeight())
+
+	//a reorg can't remove the pruned blocks
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.EqualError(t, chain.checkBlocksRemovable(reader, blocksCount-config.PRUNE_BLOCKS-1), "Pruned blocks can't be removed")
+		assert.NoError(t, chain.checkBlocksRemovable(reader, blocksCount-config.PRUNE_BLOCKS))
+		return nil
+	}))
+}
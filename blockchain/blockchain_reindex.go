@@ -0,0 +1,340 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/assets"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//indexes written only by the wallet nodes info which can be rebuilt from the stored blocks and the assets
+var reindexInfoPrefixes = []string{
+	"blockInfo_ByHash", "txHash_ByHeight", "txInfo_ByHash", "txPreview_ByHash", "txKeys:", "addrTx:", "addrTxInfo:", "addrTxsCount:",
+	"forger_ByHeight", "forgerBlock:", "forgerBlocksCount:",
+	"assetInfo_ByHash:", "assetsSearch:", "assetsSearchCount:", "assetsByTicker:", "assetsByTickerCount:", "holders:",
+}
+
+type reindexInfoStep uint8
+
+const (
+	reindexInfoStepDelete reindexInfoStep = iota
+	reindexInfoStepBlocks
+	reindexInfoStepAssets
+)
+
+//the progress of the reindex is stored in the same db tx with the indexes, so it can be resumed after an interruption
+type reindexInfoState struct {
+	Step     reindexInfoStep `msgpack:"step"`
+	Height   uint64          `msgpack:"height"`   //next block to be replayed
+	TxsCount uint64          `msgpack:"txsCount"` //txs before the next block
+	Assets   uint64          `msgpack:"assets"`   //assets already indexed
+	Key      []byte          `msgpack:"key"`      //last account inserted in the holders of the current asset
+}
+
+func loadReindexInfoState(reader store_db_interface.StoreDBTransactionInterface) (*reindexInfoState, error) {
+	data := reader.Get("reindexInfo")
+	if data == nil {
+		return nil, nil
+	}
+	state := &reindexInfoState{}
+	if err := msgpack.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func saveReindexInfoState(writer store_db_interface.StoreDBTransactionInterface, state *reindexInfoState) error {
+	data, err := msgpack.Marshal(state)
+	if err != nil {
+		return err
+	}
+	writer.Put("reindexInfo", data)
+	return nil
+}
+
+//deletes all the keys starting with the prefix in multiple db txs
+func deleteStorePrefix(prefix string) (count uint64, err error) {
+
+	for {
+
+		keys := make([]string, 0, config.STORE_DELETE_BATCH_SIZE)
+
+		if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			if err = writer.Iterate(prefix, "", false, func(key string, value []byte) bool {
+				keys = append(keys, key)
+				return len(keys) < cap(keys)
+			}); err != nil {
+				return
+			}
+
+			for _, key := range keys {
+				writer.Delete(key)
+			}
+			return
+		}); err != nil {
+			return
+		}
+
+		count += uint64(len(keys))
+		if len(keys) < cap(keys) {
+			return
+		}
+	}
+}
+
+func (chain *Blockchain) reindexInfoDelete() (err error) {
+
+	for _, prefix := range reindexInfoPrefixes {
+		var count uint64
+		if count, err = deleteStorePrefix(prefix); err != nil {
+			return
+		}
+		gui.GUI.Info("Reindex: deleted", count, prefix)
+	}
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		//the pruned blocks can't be replayed
+		height := chain.LoadPrunedHeight(writer)
+
+		chainData, err := chain.loadChainDataByHeight(writer, height)
+		if err != nil {
+			return
+		}
+
+		return saveReindexInfoState(writer, &reindexInfoState{reindexInfoStepBlocks, height, chainData.TransactionsCount, 0, nil})
+	})
+}
+
+func (chain *Blockchain) reindexInfoBlocks(state *reindexInfoState) (err error) {
+
+	chainHeight := chain.GetChainData().Height
+
+	for state.Height < chainHeight {
+
+		end := state.Height + config.STORE_REBUILD_BATCH_SIZE
+		if end > chainHeight {
+			end = chainHeight
+		}
+
+		next := &reindexInfoState{reindexInfoStepBlocks, end, state.TxsCount, 0, nil}
+
+		if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			for i := state.Height; i < end; i++ {
+
+				var blkComplete *block_complete.BlockComplete
+				if blkComplete, err = chain.loadBlockComplete(writer, i); err != nil {
+					return fmt.Errorf("Error loading block %d: %s", i, err.Error())
+				}
+
+				localTransactionChanges := make([]*blockchain_types.BlockchainTransactionUpdate, len(blkComplete.Txs))
+				for j := range localTransactionChanges {
+					localTransactionChanges[j] = &blockchain_types.BlockchainTransactionUpdate{}
+				}
+
+				if err = saveBlockCompleteInfo(writer, blkComplete, next.TxsCount, localTransactionChanges); err != nil {
+					return
+				}
+				if _, err = saveForgerBlock(writer, blkComplete); err != nil {
+					return
+				}
+				next.TxsCount += uint64(len(blkComplete.Txs))
+			}
+
+			return saveReindexInfoState(writer, next)
+		}); err != nil {
+			return
+		}
+
+		state = next
+		gui.GUI.Info2Update("Reindex", "blocks "+strconv.FormatUint(state.Height, 10)+" / "+strconv.FormatUint(chainHeight, 10))
+	}
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		return saveReindexInfoState(writer, &reindexInfoState{reindexInfoStepAssets, state.Height, state.TxsCount, 0, nil})
+	})
+}
+
+//writes the info of every asset and inserts its accounts with a positive balance in the holders
+func (chain *Blockchain) reindexInfoAssets(state *reindexInfoState) (err error) {
+
+	assetsIds := [][]byte{}
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return reader.Iterate("assets:exists:", "", false, func(key string, value []byte) bool {
+			assetsIds = append(assetsIds, []byte(key[len("assets:exists:"):]))
+			return true
+		})
+	}); err != nil {
+		return
+	}
+
+	for state.Assets < uint64(len(assetsIds)) {
+
+		assetId := assetsIds[state.Assets]
+
+		next := &reindexInfoState{reindexInfoStepAssets, state.Height, state.TxsCount, state.Assets, nil}
+
+		if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			if len(state.Key) == 0 {
+				var ast *asset.Asset
+				if ast, err = assets.NewAssets(writer).GetAsset(assetId); err != nil {
+					return
+				}
+				if ast == nil {
+					return errors.New("Asset was not found")
+				}
+				if err = saveAssetInfo(writer, string(assetId), ast, nil); err != nil {
+					return
+				}
+			}
+
+			var accs *accounts.Accounts
+			if accs, err = accounts.NewAccounts(writer, assetId); err != nil {
+				return
+			}
+
+			holders := NewAssetHolders(writer, assetId)
+
+			count := 0
+			if err = accs.HashMap.Iterate(string(state.Key), false, func(key string, element hash_map.HashMapElementSerializableInterface) (bool, error) {
+				if key == string(state.Key) {
+					return true, nil
+				}
+				if balance := element.(*account.Account).Balance; balance > 0 {
+					if err := holders.Insert(float64(balance), []byte(key)); err != nil {
+						return false, err
+					}
+				}
+				next.Key = []byte(key)
+				count += 1
+				return count < config.STORE_REBUILD_HOLDERS_BATCH_SIZE, nil
+			}); err != nil {
+				return
+			}
+
+			if err = holders.HashMap.CommitChanges(); err != nil {
+				return
+			}
+			if err = holders.DictMap.CommitChanges(); err != nil {
+				return
+			}
+
+			if count < config.STORE_REBUILD_HOLDERS_BATCH_SIZE {
+				next.Assets += 1
+				next.Key = nil
+			}
+
+			return saveReindexInfoState(writer, next)
+		}); err != nil {
+			return
+		}
+
+		state = next
+		gui.GUI.Info2Update("Reindex", "assets "+strconv.FormatUint(state.Assets, 10)+" / "+strconv.Itoa(len(assetsIds)))
+	}
+
+	return
+}
+
+//deletes the wallet nodes info indexes and writes them again by replaying the stored blocks and by walking the assets.
+//An interrupted reindex is resumed from the last batch stored
+func (chain *Blockchain) ReindexInfo() (err error) {
+
+	if !config.SEED_WALLET_NODES_INFO {
+		return errors.New("The indexes are stored only with --seed-wallet-nodes-info=\"true\"")
+	}
+
+	//no blocks can be included meanwhile
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	defer gui.GUI.Info2Update("Reindex", "")
+
+	var state *reindexInfoState
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		state, err = loadReindexInfoState(reader)
+		return
+	}); err != nil {
+		return
+	}
+
+	if state == nil {
+		gui.GUI.Info("Reindex: started")
+		state = &reindexInfoState{}
+		if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			return saveReindexInfoState(writer, state)
+		}); err != nil {
+			return
+		}
+	} else {
+		gui.GUI.Info("Reindex: resumed from step", state.Step, "height", state.Height, "assets", state.Assets)
+	}
+
+	for {
+
+		switch state.Step {
+		case reindexInfoStepDelete:
+			gui.GUI.Info2Update("Reindex", "deleting")
+			err = chain.reindexInfoDelete()
+		case reindexInfoStepBlocks:
+			err = chain.reindexInfoBlocks(state)
+		case reindexInfoStepAssets:
+			if err = chain.reindexInfoAssets(state); err != nil {
+				return
+			}
+			if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+				writer.Delete("reindexInfo")
+				return nil
+			}); err != nil {
+				return
+			}
+			gui.GUI.Info("Reindex: finished")
+			return
+		default:
+			return errors.New("Reindex step is invalid")
+		}
+		if err != nil {
+			return
+		}
+
+		if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			state, err = loadReindexInfoState(reader)
+			return
+		}); err != nil {
+			return
+		}
+	}
+}
+
+//a reindex interrupted by a shutdown is finished before the node includes new blocks
+func (chain *Blockchain) resumeReindexInfo() (err error) {
+
+	var state *reindexInfoState
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		state, err = loadReindexInfoState(reader)
+		return
+	}); err != nil || state == nil {
+		return
+	}
+
+	if !config.SEED_WALLET_NODES_INFO {
+		gui.GUI.Warning("Reindex was interrupted. It will be resumed when the node is started with --seed-wallet-nodes-info=\"true\"")
+		return
+	}
+
+	return chain.ReindexInfo()
+}
@@ -0,0 +1,318 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/store"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/min_max_heap"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"sort"
+	"sync"
+	"testing"
+)
+
+//stops the reindex like a shutdown. The db tx after the one which stored a state accepted by interrupt and all the next ones fail
+type reindexTestStoreDB struct {
+	store_db_interface.StoreDBInterface
+	interrupt   func(state *reindexInfoState) bool
+	interrupted bool
+}
+
+func (db *reindexTestStoreDB) Update(callback func(dbTx store_db_interface.StoreDBTransactionInterface) error) error {
+
+	if db.interrupted {
+		return errors.New("interrupted")
+	}
+
+	if err := db.StoreDBInterface.Update(callback); err != nil {
+		return err
+	}
+
+	return db.StoreDBInterface.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		var state *reindexInfoState
+		if state, err = loadReindexInfoState(reader); err != nil {
+			return
+		}
+		db.interrupted = state != nil && db.interrupt != nil && db.interrupt(state)
+		return
+	})
+}
+
+func createReindexTestBlock(t *testing.T, privateKey *addresses.PrivateKey, height uint64, prevHash []byte, txs []*transaction.Transaction) *block_complete.BlockComplete {
+
+	blkComplete := &block_complete.BlockComplete{
+		Block: &block.Block{
+			BlockHeader:             &block.BlockHeader{Version: block.GetBlockVersion(height), Height: height},
+			StateRoot:               cryptography.SHA3(helpers.RandomBytes(32)),
+			PrevHash:                prevHash,
+			PrevKernelHash:          prevHash,
+			StakingAmount:           1000,
+			Timestamp:               1000 + height,
+			Forger:                  privateKey.GeneratePublicKeyHash(),
+			DelegatedStakePublicKey: privateKey.GeneratePublicKey(),
+		},
+		Txs: txs,
+	}
+	blkComplete.Block.MerkleHash = blkComplete.MerkleHash()
+
+	signature, err := privateKey.Sign(blkComplete.Block.SerializeForSigning())
+	assert.NoError(t, err)
+	blkComplete.Block.Signature = signature
+
+	assert.NoError(t, blkComplete.BloomAll())
+	return blkComplete
+}
+
+//the wallet nodes info indexes, except the holders whose heap layout depends on the insertion order.
+//The keys of a tx are sorted because they are stored in the order of a map
+func dumpReindexTestInfo(t *testing.T) map[string]string {
+	out := make(map[string]string)
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for _, prefix := range reindexInfoPrefixes {
+			if prefix == "holders:" {
+				continue
+			}
+			if err := reader.Iterate(prefix, "", false, func(key string, value []byte) bool {
+				if prefix == "txKeys:" {
+					keys := [][]byte{}
+					assert.NoError(t, msgpack.Unmarshal(value, &keys))
+					sort.Slice(keys, func(i, j int) bool {
+						return bytes.Compare(keys[i], keys[j]) < 0
+					})
+					out[key] = fmt.Sprint(keys)
+				} else {
+					out[key] = string(value)
+				}
+				return true
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	return out
+}
+
+func dumpReindexTestHolders(t *testing.T, assetId []byte) (size uint64, out map[string]float64) {
+	out = make(map[string]float64)
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		holders := NewAssetHolders(reader, assetId)
+		size = holders.GetSize()
+		return holders.HashMap.Iterate("", false, func(key string, element hash_map.HashMapElementSerializableInterface) (bool, error) {
+			out[string(element.(*min_max_heap.HeapElement).Key)] = element.(*min_max_heap.HeapElement).Score
+			return true, nil
+		})
+	}))
+	return
+}
+
+func TestBlockchain_ReindexInfo(t *testing.T) {
+
+	defer func(storeBlockchain *store.Store, height uint64, seed bool) {
+		store.StoreBlockchain = storeBlockchain
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+		config.SEED_WALLET_NODES_INFO = seed
+	}(store.StoreBlockchain, config.BLOCK_STATE_ROOT_HEIGHT, config.SEED_WALLET_NODES_INFO)
+	config.BLOCK_STATE_ROOT_HEIGHT = 0
+	config.SEED_WALLET_NODES_INFO = true
+
+	var err error
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.NoError(t, err)
+	}
+
+	memoryDB, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+	db := &reindexTestStoreDB{StoreDBInterface: memoryDB}
+	store.StoreBlockchain = &store.Store{Name: "blockchain", DB: db}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.NoError(t, err)
+
+	//one more block and one more holder than a batch, so the reindex is resumed in the middle of both
+	blocksCount := uint64(config.STORE_REBUILD_BATCH_SIZE + 1)
+	holdersCount := config.STORE_REBUILD_HOLDERS_BATCH_SIZE + 1
+
+	chain := &Blockchain{ChainData: &generics.Value[*BlockchainData]{}, mutex: &sync.Mutex{}}
+
+	//the blocks are stored like a node synced with --seed-wallet-nodes-info
+	chainData := &BlockchainData{Hash: cryptography.SHA3([]byte("Genesis")), AssetsCount: 1}
+	for height := uint64(0); height < blocksCount; height++ {
+
+		tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+			nil,
+			&wizard.WizardTransactionData{},
+			&wizard.WizardTransactionFee{},
+			height,
+			[]*wizard.WizardTxSimpleTransferVin{{privateKey.Key, 10, config_coins.NATIVE_ASSET_FULL, nil}},
+			[]*wizard.WizardTxSimpleTransferVout{{helpers.RandomBytes(cryptography.PublicKeyHashSize), 10, config_coins.NATIVE_ASSET_FULL}},
+		}, true, func(string) {})
+		assert.NoError(t, err)
+
+		blkComplete := createReindexTestBlock(t, privateKey, height, chainData.Hash, []*transaction.Transaction{tx})
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			dataStorage := data_storage.NewDataStorage(writer)
+
+			if height == 0 {
+
+				if err = dataStorage.Asts.CreateAsset(config_coins.NATIVE_ASSET_FULL, &asset.Asset{
+					PublicKeyHash:    config_coins.NATIVE_ASSET_FULL,
+					DecimalSeparator: byte(config_coins.DECIMAL_SEPARATOR),
+					MaxSupply:        config_coins.MAX_SUPPLY_COINS_UNITS,
+					Supply:           1000,
+					UpdatePublicKey:  config_coins.BURN_PUBLIC_KEY,
+					SupplyPublicKey:  config_coins.BURN_PUBLIC_KEY,
+					Name:             config_coins.NATIVE_ASSET_NAME,
+					Ticker:           config_coins.NATIVE_ASSET_TICKER,
+					Identification:   config_coins.NATIVE_ASSET_IDENTIFICATION,
+					Description:      config_coins.NATIVE_ASSET_DESCRIPTION,
+				}); err != nil {
+					return
+				}
+
+				//the accounts without balance are walked but they are not holders
+				for i := 0; i < holdersCount; i++ {
+					accs, acc, err := dataStorage.CreateAccount(config_coins.NATIVE_ASSET_FULL, helpers.RandomBytes(cryptography.PublicKeyHashSize))
+					if err != nil {
+						return err
+					}
+					if err = acc.AddBalance(true, uint64(i%7)); err != nil {
+						return err
+					}
+					if err = accs.Update(string(acc.Key), acc); err != nil {
+						return err
+					}
+				}
+			}
+
+			if _, err = chain.saveBlockComplete(writer, blkComplete, chainData.TransactionsCount, map[string][]byte{}, nil, dataStorage); err != nil {
+				return
+			}
+			if _, err = saveForgerBlock(writer, blkComplete); err != nil {
+				return
+			}
+			return chain.saveBlockchainHashmaps(dataStorage)
+		}))
+
+		chainData.Height = height + 1
+		chainData.Hash = blkComplete.Block.Bloom.Hash
+		chainData.TransactionsCount += 1
+	}
+	chainData.Supply = 1000
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		chainData.saveBlockchainHeight(writer)
+		return chainData.saveBlockchain(writer)
+	}))
+
+	chain.ChainData.Store(chainData)
+
+	mismatches, err := chain.VerifyStore()
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	synced := dumpReindexTestInfo(t)
+	syncedHoldersSize, syncedHolders := dumpReindexTestHolders(t, config_coins.NATIVE_ASSET_FULL)
+	assert.Equal(t, int(syncedHoldersSize), len(syncedHolders))
+	assert.Less(t, len(syncedHolders), holdersCount)
+
+	loadState := func() (state *reindexInfoState) {
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			state, err = loadReindexInfoState(reader)
+			return
+		}))
+		return
+	}
+
+	//stopped after the indexes were deleted
+	db.interrupt = func(state *reindexInfoState) bool {
+		return state.Step == reindexInfoStepBlocks
+	}
+	assert.EqualError(t, chain.ReindexInfo(), "interrupted")
+	assert.Equal(t, &reindexInfoState{reindexInfoStepBlocks, 0, 0, 0, nil}, loadState())
+	assert.Empty(t, dumpReindexTestInfo(t))
+
+	mismatches, err = chain.VerifyStore()
+	assert.NoError(t, err)
+	assert.Contains(t, mismatches, "the wallet nodes info reindex was interrupted and it is not finished")
+
+	//stopped after the first batch of blocks
+	db.interrupted = false
+	db.interrupt = func(state *reindexInfoState) bool {
+		return state.Step == reindexInfoStepBlocks && state.Height > 0
+	}
+	assert.EqualError(t, chain.resumeReindexInfo(), "interrupted")
+	assert.Equal(t, &reindexInfoState{reindexInfoStepBlocks, config.STORE_REBUILD_BATCH_SIZE, config.STORE_REBUILD_BATCH_SIZE, 0, nil}, loadState())
+
+	//stopped after the first batch of holders
+	db.interrupted = false
+	db.interrupt = func(state *reindexInfoState) bool {
+		return state.Step == reindexInfoStepAssets && len(state.Key) > 0
+	}
+	assert.EqualError(t, chain.resumeReindexInfo(), "interrupted")
+
+	state := loadState()
+	assert.Equal(t, reindexInfoStepAssets, state.Step)
+	assert.Equal(t, blocksCount, state.Height)
+	assert.Equal(t, blocksCount, state.TxsCount)
+	assert.Equal(t, uint64(0), state.Assets)
+	assert.NotEmpty(t, state.Key)
+
+	//the batch inserted the accounts walked up to the key
+	walkedHolders := make(map[string]float64)
+	for key, score := range syncedHolders {
+		if key <= string(state.Key) {
+			walkedHolders[key] = score
+		}
+	}
+	size, holders := dumpReindexTestHolders(t, config_coins.NATIVE_ASSET_FULL)
+	assert.Equal(t, uint64(len(walkedHolders)), size)
+	assert.Equal(t, walkedHolders, holders)
+
+	//an interrupted reindex is resumed only with --seed-wallet-nodes-info
+	db.interrupted = false
+	db.interrupt = nil
+	config.SEED_WALLET_NODES_INFO = false
+	assert.NoError(t, chain.resumeReindexInfo())
+	assert.Equal(t, state, loadState())
+	config.SEED_WALLET_NODES_INFO = true
+
+	//the key stored is walked again by the seek and it is not inserted twice
+	assert.NoError(t, chain.resumeReindexInfo())
+	assert.Nil(t, loadState())
+
+	mismatches, err = chain.VerifyStore()
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	assert.Equal(t, synced, dumpReindexTestInfo(t))
+	size, holders = dumpReindexTestHolders(t, config_coins.NATIVE_ASSET_FULL)
+	assert.Equal(t, syncedHoldersSize, size)
+	assert.Equal(t, syncedHolders, holders)
+}
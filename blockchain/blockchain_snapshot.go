@@ -0,0 +1,450 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/vmihailenco/msgpack/v5"
+	"math/big"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block/difficulty"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/mempool"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"time"
+)
+
+//the state after a block whose height is a multiple of config.SNAPSHOT_HEIGHT_INTERVAL is stored to be served to the new nodes
+type snapshotStoredInfo struct {
+	Hash   []byte `json:"hash" msgpack:"hash"`
+	Chunks int    `json:"chunks" msgpack:"chunks"`
+}
+
+func loadSnapshotStoredInfo(reader store_db_interface.StoreDBTransactionInterface, height uint64) (*snapshotStoredInfo, error) {
+	data := reader.Get("snapshot:" + strconv.FormatUint(height, 10))
+	if data == nil {
+		return nil, nil
+	}
+	info := &snapshotStoredInfo{}
+	if err := msgpack.Unmarshal(data, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func deleteSnapshotStored(writer store_db_interface.StoreDBTransactionInterface, height uint64) error {
+
+	info, err := loadSnapshotStoredInfo(writer, height)
+	if err != nil || info == nil {
+		return err
+	}
+
+	heightStr := strconv.FormatUint(height, 10)
+	for i := 0; i < info.Chunks; i++ {
+		writer.Delete("snapshot:" + heightStr + ":chunk:" + strconv.Itoa(i))
+	}
+	writer.Delete("snapshot:" + heightStr)
+	return nil
+}
+
+//chain must be locked before. The state must be the one after the block was included
+func (chain *Blockchain) saveSnapshot(writer store_db_interface.StoreDBTransactionInterface, blk *block.Block, dataStorage *data_storage.DataStorage) error {
+
+	if blk.Height == 0 || blk.Height%config.SNAPSHOT_HEIGHT_INTERVAL != 0 || !blk.HasStateRoot() {
+		return nil
+	}
+
+	//the snapshot of a removed block is replaced
+	if err := deleteSnapshotStored(writer, blk.Height); err != nil {
+		return err
+	}
+	if blk.Height >= config.SNAPSHOT_KEEP*config.SNAPSHOT_HEIGHT_INTERVAL {
+		if err := deleteSnapshotStored(writer, blk.Height-config.SNAPSHOT_KEEP*config.SNAPSHOT_HEIGHT_INTERVAL); err != nil {
+			return err
+		}
+	}
+
+	chunks, err := dataStorage.ExportSnapshot(config.SNAPSHOT_CHUNK_SIZE)
+	if err != nil {
+		return err
+	}
+	if len(chunks) > config.SNAPSHOT_CHUNKS_MAX {
+		return errors.New("Snapshot has too many chunks")
+	}
+
+	heightStr := strconv.FormatUint(blk.Height, 10)
+	for i, chunk := range chunks {
+		writer.Put("snapshot:"+heightStr+":chunk:"+strconv.Itoa(i), chunk)
+	}
+
+	data, err := msgpack.Marshal(&snapshotStoredInfo{blk.Bloom.Hash, len(chunks)})
+	if err != nil {
+		return err
+	}
+	writer.Put("snapshot:"+heightStr, data)
+
+	return nil
+}
+
+//returns the hash of the block and the number of chunks of the stored snapshot
+func (chain *Blockchain) OpenLoadSnapshotInfo(height uint64) (hash []byte, chunks int, errFinal error) {
+	errFinal = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		var info *snapshotStoredInfo
+		if info, err = loadSnapshotStoredInfo(reader, height); err != nil {
+			return
+		}
+		if info == nil {
+			return errors.New("Snapshot was not found")
+		}
+		hash, chunks = info.Hash, info.Chunks
+		return
+	})
+	return
+}
+
+func (chain *Blockchain) OpenLoadSnapshotChunk(height uint64, hash []byte, index int) (data []byte, errFinal error) {
+	errFinal = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		var info *snapshotStoredInfo
+		if info, err = loadSnapshotStoredInfo(reader, height); err != nil {
+			return
+		}
+		if info == nil || !bytes.Equal(info.Hash, hash) {
+			return errors.New("Snapshot was changed")
+		}
+		if index < 0 || index >= info.Chunks {
+			return errors.New("Snapshot chunk index is invalid")
+		}
+
+		if data = reader.Get("snapshot:" + strconv.FormatUint(height, 10) + ":chunk:" + strconv.Itoa(index)); data == nil {
+			return errors.New("Snapshot chunk was not found")
+		}
+		return
+	})
+	return
+}
+
+//returns the serialized headers starting with the height
+func (chain *Blockchain) OpenLoadBlocksHeaders(start uint64, count int) (headers [][]byte, errFinal error) {
+	errFinal = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+		for height := start; height < chainHeight && len(headers) < count; height++ {
+
+			var hash []byte
+			if hash, err = chain.LoadBlockHash(reader, height); err != nil {
+				return
+			}
+
+			data := reader.Get("block_ByHash" + string(hash))
+			if data == nil {
+				return errors.New("Block was not found")
+			}
+			headers = append(headers, data)
+		}
+		return
+	})
+	return
+}
+
+//the headers from genesis to the checkpoint are verified and the chain data is derived from them, hence the difficulty is not received from the peers
+type BlockchainSnapshotHeaders struct {
+	ChainData        *BlockchainData
+	Block            *block.Block //the last verified header
+	checkpointHeight uint64
+	checkpointHash   []byte
+	difficulties     *store_db_memory.StoreDBMemory //the total difficulties of the last config.DIFFICULTY_BLOCK_WINDOW blocks
+}
+
+func newBlockchainSnapshotHeaders(genesisChainData *BlockchainData, checkpointHeight uint64, checkpointHash []byte) (*BlockchainSnapshotHeaders, error) {
+
+	difficulties, err := store_db_memory.CreateStoreDBMemory("snapshot")
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockchainSnapshotHeaders{
+		genesisChainData,
+		nil,
+		checkpointHeight,
+		helpers.CloneBytes(checkpointHash),
+		difficulties,
+	}, nil
+}
+
+func (chain *Blockchain) NewBlockchainSnapshotHeaders(checkpointHeight uint64, checkpointHash []byte) (*BlockchainSnapshotHeaders, error) {
+	return newBlockchainSnapshotHeaders(chain.createGenesisBlockchainData(), checkpointHeight, checkpointHash)
+}
+
+//height of the next required header
+func (headers *BlockchainSnapshotHeaders) Next() uint64 {
+	return headers.ChainData.Height
+}
+
+func (headers *BlockchainSnapshotHeaders) Done() bool {
+	return headers.Block != nil && headers.Block.Height == headers.checkpointHeight
+}
+
+//the headers must follow the last verified header. The checks done by AddBlocks without the state are done. After an error the headers can't be used anymore
+func (headers *BlockchainSnapshotHeaders) AddHeaders(serialized [][]byte) error {
+	return headers.difficulties.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		defer func() {
+			if errReturned := recover(); errReturned != nil {
+				err = fmt.Errorf("Snapshot headers are invalid: %v", errReturned)
+			}
+		}()
+
+		chainData := headers.ChainData
+
+		for _, data := range serialized {
+
+			if headers.Done() {
+				return errors.New("Snapshot headers are after the checkpoint")
+			}
+
+			blk := block.CreateEmptyBlock()
+			if err = blk.Deserialize(helpers.NewBufferReader(data)); err != nil {
+				return
+			}
+			if err = blk.BloomNow(); err != nil {
+				return
+			}
+
+			if blk.Height != chainData.Height {
+				return errors.New("Snapshot header height is invalid")
+			}
+			if !bytes.Equal(blk.PrevHash, chainData.Hash) || !bytes.Equal(blk.PrevKernelHash, chainData.KernelHash) {
+				return errors.New("Snapshot headers are not linked")
+			}
+			if blk.StakingAmount < config_stake.GetRequiredStake(blk.Height) {
+				return errors.New("Snapshot header stake amount is not enough")
+			}
+			if difficulty.CheckKernelHashBig(blk.Bloom.KernelHashStaked, chainData.Target) != true {
+				return errors.New("Snapshot header KernelHash Difficulty is not met")
+			}
+			if blk.Timestamp < chainData.Timestamp {
+				return errors.New("Snapshot header timestamp has to be greater than the last timestamp")
+			}
+			if blk.Timestamp > uint64(time.Now().UTC().Unix())+config.NETWORK_TIMESTAMP_DRIFT_MAX {
+				return errors.New("Snapshot header timestamp is too much into the future")
+			}
+			if blk.Height == headers.checkpointHeight && !bytes.Equal(blk.Bloom.Hash, headers.checkpointHash) {
+				return errors.New("Snapshot checkpoint hash is not matching")
+			}
+
+			chainData.PrevHash = chainData.Hash
+			chainData.Hash = blk.Bloom.Hash
+			chainData.PrevKernelHash = chainData.KernelHash
+			chainData.KernelHash = blk.Bloom.KernelHash
+			chainData.Timestamp = blk.Timestamp
+
+			difficultyBigInt := difficulty.ConvertTargetToDifficulty(chainData.Target)
+			chainData.BigTotalDifficulty = new(big.Int).Add(chainData.BigTotalDifficulty, difficultyBigInt)
+
+			if chainData.Target, err = chainData.computeNextTargetBig(writer); err != nil {
+				return
+			}
+
+			chainData.Height += 1
+			chainData.saveTotalDifficultyExtra(writer)
+
+			if chainData.Height > config.DIFFICULTY_BLOCK_WINDOW {
+				writer.Delete("totalDifficulty" + strconv.FormatUint(chainData.Height-config.DIFFICULTY_BLOCK_WINDOW, 10))
+			}
+
+			headers.Block = blk
+		}
+
+		return
+	})
+}
+
+//the state is verified using the state root of the block
+func importSnapshotState(dataStorage *data_storage.DataStorage, blk *block.Block, chunks [][]byte) (err error) {
+
+	if !blk.HasStateRoot() {
+		return errors.New("Snapshot block has no state root")
+	}
+
+	if err = dataStorage.ClearState(); err != nil {
+		return
+	}
+	if err = dataStorage.CommitChanges(); err != nil {
+		return
+	}
+
+	for _, chunk := range chunks {
+		if err = dataStorage.ImportSnapshotChunk(chunk); err != nil {
+			return
+		}
+	}
+	if err = dataStorage.CommitChanges(); err != nil {
+		return
+	}
+
+	var stateRoot []byte
+	if stateRoot, err = dataStorage.ComputeStateRoot(); err != nil {
+		return
+	}
+	if !bytes.Equal(blk.StateRoot, stateRoot) {
+		return fmt.Errorf("Snapshot State Root is not matching the block %d", blk.Height)
+	}
+
+	return
+}
+
+//replaces the state of a new chain with the state after the checkpoint block. The blocks after it are included by AddBlocks
+func (chain *Blockchain) ImportSnapshot(headers *BlockchainSnapshotHeaders, chunks [][]byte) (err error) {
+
+	if !headers.Done() {
+		return errors.New("Snapshot headers were not verified")
+	}
+
+	blk := headers.Block
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	if chain.GetChainData().Height >= headers.ChainData.Height {
+		return errors.New("Chain is already ahead of the snapshot")
+	}
+
+	gui.GUI.Info("Importing snapshot " + strconv.FormatUint(blk.Height, 10))
+
+	//the txs before the snapshot are not stored, so they are not counted
+	newChainData := &BlockchainData{
+		helpers.CloneBytes(headers.ChainData.Hash),
+		helpers.CloneBytes(headers.ChainData.PrevHash),
+		helpers.CloneBytes(headers.ChainData.KernelHash),
+		helpers.CloneBytes(headers.ChainData.PrevKernelHash),
+		headers.ChainData.Height,
+		headers.ChainData.Timestamp,
+		new(big.Int).Set(headers.ChainData.Target),
+		new(big.Int).Set(headers.ChainData.BigTotalDifficulty),
+		0,
+		0,
+		0,
+		0,
+		0,
+	}
+
+	var dataStorage *data_storage.DataStorage
+
+	chain.mempool.SuspendProcessingCn <- struct{}{}
+
+	err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		defer func() {
+			if errReturned := recover(); errReturned != nil {
+				err = fmt.Errorf("Snapshot is invalid: %v", errReturned)
+			}
+		}()
+
+		dataStorage = data_storage.NewDataStorage(writer)
+
+		if err = importSnapshotState(dataStorage, blk, chunks); err != nil {
+			return
+		}
+
+		var ast *asset.Asset
+		if ast, err = dataStorage.Asts.GetAsset(config_coins.NATIVE_ASSET_FULL); err != nil {
+			return
+		}
+		if ast == nil {
+			return errors.New("Snapshot has no native asset")
+		}
+		newChainData.Supply = ast.Supply
+
+		if err = headers.difficulties.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			first := uint64(1)
+			if newChainData.Height > config.DIFFICULTY_BLOCK_WINDOW {
+				first = newChainData.Height - config.DIFFICULTY_BLOCK_WINDOW + 1
+			}
+			for height := first; height <= newChainData.Height; height++ {
+				key := "totalDifficulty" + strconv.FormatUint(height, 10)
+				data := reader.Get(key)
+				if data == nil {
+					return errors.New("Couldn't read difficulty")
+				}
+				writer.Put(key, data)
+			}
+			return nil
+		}); err != nil {
+			return
+		}
+
+		blockHeightStr := strconv.FormatUint(blk.Height, 10)
+		writer.Put("block_ByHash"+string(blk.Bloom.Hash), blk.Bloom.Serialized)
+		writer.Put("blockHash_ByHeight"+blockHeightStr, blk.Bloom.Hash)
+		writer.Put("blockKernelHash_ByHeight"+blockHeightStr, blk.Bloom.KernelHash)
+		writer.Put("blockHeight_ByHash"+string(blk.Bloom.Hash), []byte(blockHeightStr))
+
+		//blocks before the snapshot can't be removed
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, newChainData.Height)
+		writer.Put("chainSnapshotHeight", buf[:n])
+
+		newChainData.AssetsCount = dataStorage.Asts.Count
+		newChainData.AccountsCount = dataStorage.PlainAccs.Count
+
+		newChainData.saveBlockchainHeight(writer)
+		if err = newChainData.saveBlockchainInfo(writer); err != nil {
+			return
+		}
+		if err = newChainData.saveBlockchain(writer); err != nil {
+			return
+		}
+
+		if err = chain.saveBlockchainHashmaps(dataStorage); err != nil {
+			return
+		}
+
+		dataStorage.SetTx(nil)
+		return
+	})
+
+	if err != nil {
+		chain.mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_ERROR
+		return
+	}
+
+	chain.ChainData.Store(newChainData)
+	chain.mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_NO_ERROR
+
+	chain.updatesQueue.updatesCn <- &BlockchainUpdate{
+		newChainData:           newChainData,
+		dataStorage:            dataStorage,
+		allTransactionsChanges: []*blockchain_types.BlockchainTransactionUpdate{},
+		removedTxHashes:        make(map[string][]byte),
+		insertedTxs:            make(map[string]*transaction.Transaction),
+		insertedBlocks:         []*block_complete.BlockComplete{},
+		exceptSocketUUID:       advanced_connection_types.UUID_ALL,
+	}
+
+	gui.GUI.Info("Snapshot imported " + strconv.FormatUint(newChainData.Height, 10))
+
+	return
+}
+
+func (chain *Blockchain) loadSnapshotHeight(reader store_db_interface.StoreDBTransactionInterface) uint64 {
+	if data := reader.Get("chainSnapshotHeight"); data != nil {
+		height, _ := binary.Uvarint(data)
+		return height
+	}
+	return 0
+}
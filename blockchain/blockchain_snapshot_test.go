@@ -0,0 +1,255 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func createSnapshotTestGenesis() *BlockchainData {
+	return &BlockchainData{
+		Hash:               cryptography.SHA3([]byte("Genesis")),
+		PrevHash:           cryptography.SHA3([]byte("Genesis")),
+		KernelHash:         cryptography.SHA3([]byte("GenesisKernel")),
+		PrevKernelHash:     cryptography.SHA3([]byte("GenesisKernel")),
+		Timestamp:          uint64(time.Now().Unix()) - 1000,
+		Target:             new(big.Int).Set(config.BIG_INT_MAX_256),
+		BigTotalDifficulty: new(big.Int).SetUint64(0),
+	}
+}
+
+func createSnapshotTestHeader(t *testing.T, privateKey *addresses.PrivateKey, height uint64, prevHash, prevKernelHash []byte, timestamp uint64) *block.Block {
+
+	blk := &block.Block{
+		BlockHeader:             &block.BlockHeader{Version: block.GetBlockVersion(height), Height: height},
+		MerkleHash:              cryptography.SHA3([]byte{}),
+		StateRoot:               cryptography.SHA3([]byte("StateRoot" + strconv.FormatUint(height, 10))),
+		PrevHash:                prevHash,
+		PrevKernelHash:          prevKernelHash,
+		Timestamp:               timestamp,
+		StakingAmount:           config_stake.GetRequiredStake(height),
+		Forger:                  helpers.RandomBytes(cryptography.PublicKeyHashSize),
+		DelegatedStakePublicKey: privateKey.GeneratePublicKey(),
+	}
+
+	var err error
+	blk.Signature, err = privateKey.Sign(blk.SerializeForSigning())
+	assert.NoError(t, err)
+	assert.NoError(t, blk.BloomNow())
+
+	return blk
+}
+
+func createSnapshotTestHeaders(t *testing.T, genesis *BlockchainData, count int) ([]*block.Block, *addresses.PrivateKey) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.NoError(t, err)
+
+	blocks := make([]*block.Block, count)
+	prevHash, prevKernelHash := genesis.Hash, genesis.KernelHash
+	for i := range blocks {
+		blocks[i] = createSnapshotTestHeader(t, privateKey, uint64(i), prevHash, prevKernelHash, genesis.Timestamp+uint64(i))
+		prevHash, prevKernelHash = blocks[i].Bloom.Hash, blocks[i].Bloom.KernelHash
+	}
+
+	return blocks, privateKey
+}
+
+func getSnapshotTestSerialized(blocks []*block.Block) [][]byte {
+	out := make([][]byte, len(blocks))
+	for i, blk := range blocks {
+		out[i] = blk.Bloom.Serialized
+	}
+	return out
+}
+
+func TestBlockchainSnapshotHeaders_Verify(t *testing.T) {
+
+	defer func(height uint64) {
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+	}(config.BLOCK_STATE_ROOT_HEIGHT)
+	config.BLOCK_STATE_ROOT_HEIGHT = 0
+
+	genesis := createSnapshotTestGenesis()
+	blocks, _ := createSnapshotTestHeaders(t, genesis, 5)
+	checkpoint := blocks[4]
+
+	headers, err := newBlockchainSnapshotHeaders(genesis, checkpoint.Height, checkpoint.Bloom.Hash)
+	assert.NoError(t, err)
+
+	assert.NoError(t, headers.AddHeaders(getSnapshotTestSerialized(blocks[:2])))
+	assert.Equal(t, uint64(2), headers.Next())
+	assert.False(t, headers.Done())
+
+	assert.NoError(t, headers.AddHeaders(getSnapshotTestSerialized(blocks[2:])))
+	assert.True(t, headers.Done())
+	assert.Equal(t, checkpoint.Bloom.Hash, headers.Block.Bloom.Hash)
+
+	//the chain data is derived from the headers
+	assert.Equal(t, uint64(5), headers.ChainData.Height)
+	assert.Equal(t, checkpoint.Bloom.Hash, headers.ChainData.Hash)
+	assert.Equal(t, checkpoint.Bloom.KernelHash, headers.ChainData.KernelHash)
+	assert.Equal(t, checkpoint.Timestamp, headers.ChainData.Timestamp)
+	assert.Equal(t, uint64(5), headers.ChainData.BigTotalDifficulty.Uint64())
+
+	//no header is accepted after the checkpoint
+	assert.Error(t, headers.AddHeaders(getSnapshotTestSerialized(blocks[4:])))
+}
+
+func TestBlockchainSnapshotHeaders_TamperedHeader(t *testing.T) {
+
+	defer func(height uint64) {
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+	}(config.BLOCK_STATE_ROOT_HEIGHT)
+	config.BLOCK_STATE_ROOT_HEIGHT = 0
+
+	genesis := createSnapshotTestGenesis()
+	blocks, privateKey := createSnapshotTestHeaders(t, genesis, 5)
+	checkpoint := blocks[4]
+
+	newHeaders := func() *BlockchainSnapshotHeaders {
+		chainData := *genesis
+		headers, err := newBlockchainSnapshotHeaders(&chainData, checkpoint.Height, checkpoint.Bloom.Hash)
+		assert.NoError(t, err)
+		return headers
+	}
+
+	assert.NoError(t, newHeaders().AddHeaders(getSnapshotTestSerialized(blocks)))
+
+	//a header changed and signed again is not linked with the next header
+	tampered := append([]*block.Block{}, blocks...)
+	tampered[2] = createSnapshotTestHeader(t, privateKey, 2, blocks[1].Bloom.Hash, blocks[1].Bloom.KernelHash, blocks[2].Timestamp+1)
+
+	headers := newHeaders()
+	assert.EqualError(t, headers.AddHeaders(getSnapshotTestSerialized(tampered)), "Snapshot headers are not linked")
+	assert.False(t, headers.Done())
+
+	//a header changed without signing it again is rejected
+	serialized := getSnapshotTestSerialized(blocks)
+	serialized[2] = helpers.CloneBytes(serialized[2])
+	serialized[2][len(serialized[2])-cryptography.SignatureSize-1] ^= 1
+
+	headers = newHeaders()
+	assert.Error(t, headers.AddHeaders(serialized))
+	assert.False(t, headers.Done())
+
+	//the checkpoint header with another state root is not the trusted one
+	tampered = append([]*block.Block{}, blocks[:4]...)
+	tampered = append(tampered, createSnapshotTestHeader(t, privateKey, 4, blocks[3].Bloom.Hash, blocks[3].Bloom.KernelHash, checkpoint.Timestamp))
+
+	headers = newHeaders()
+	assert.EqualError(t, headers.AddHeaders(getSnapshotTestSerialized(tampered)), "Snapshot checkpoint hash is not matching")
+	assert.False(t, headers.Done())
+}
+
+func createSnapshotTestState(t *testing.T) (stateRoot []byte, chunks [][]byte) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(dbTx)
+
+		if err = dataStorage.Asts.CreateAsset(config_coins.NATIVE_ASSET_FULL, &asset.Asset{
+			PublicKeyHash:    config_coins.NATIVE_ASSET_FULL,
+			DecimalSeparator: byte(config_coins.DECIMAL_SEPARATOR),
+			MaxSupply:        config_coins.MAX_SUPPLY_COINS_UNITS,
+			Supply:           1000,
+			UpdatePublicKey:  config_coins.BURN_PUBLIC_KEY,
+			SupplyPublicKey:  config_coins.BURN_PUBLIC_KEY,
+			Name:             config_coins.NATIVE_ASSET_NAME,
+			Ticker:           config_coins.NATIVE_ASSET_TICKER,
+			Identification:   config_coins.NATIVE_ASSET_IDENTIFICATION,
+			Description:      config_coins.NATIVE_ASSET_DESCRIPTION,
+		}); err != nil {
+			return
+		}
+
+		for i := 0; i < 10; i++ {
+			publicKeyHash := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+
+			var plainAcc *plain_account.PlainAccount
+			if plainAcc, err = dataStorage.CreatePlainAccount(publicKeyHash); err != nil {
+				return
+			}
+			if err = plainAcc.IncrementNonce(true); err != nil {
+				return
+			}
+			if err = dataStorage.PlainAccs.Update(string(publicKeyHash), plainAcc); err != nil {
+				return
+			}
+			if _, _, err = dataStorage.CreateAccount(config_coins.NATIVE_ASSET_FULL, publicKeyHash); err != nil {
+				return
+			}
+		}
+
+		if err = dataStorage.CommitChanges(); err != nil {
+			return
+		}
+		if stateRoot, err = dataStorage.ComputeStateRoot(); err != nil {
+			return
+		}
+		chunks, err = dataStorage.ExportSnapshot(256)
+		return
+	}))
+
+	return
+}
+
+func TestBlockchainSnapshot_TamperedChunk(t *testing.T) {
+
+	defer func(height uint64) {
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+	}(config.BLOCK_STATE_ROOT_HEIGHT)
+	config.BLOCK_STATE_ROOT_HEIGHT = 0
+
+	stateRoot, chunks := createSnapshotTestState(t)
+	assert.Greater(t, len(chunks), 1)
+
+	blk := &block.Block{
+		BlockHeader: &block.BlockHeader{Version: block.GetBlockVersion(10), Height: 10},
+		StateRoot:   stateRoot,
+	}
+
+	importState := func(chunks [][]byte) error {
+		db, err := store_db_memory.CreateStoreDBMemory("")
+		assert.NoError(t, err)
+		return db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			return importSnapshotState(data_storage.NewDataStorage(dbTx), blk, chunks)
+		})
+	}
+
+	assert.NoError(t, importState(chunks))
+
+	//the key of the first plain account is changed
+	tampered := append([][]byte{}, chunks...)
+	tampered[0] = helpers.CloneBytes(chunks[0])
+	tampered[0][5] ^= 1
+	assert.EqualError(t, importState(tampered), "Snapshot State Root is not matching the block 10")
+
+	//a missing chunk
+	assert.EqualError(t, importState(chunks[1:]), "Snapshot State Root is not matching the block 10")
+
+	//the state root of another block
+	blk.StateRoot = cryptography.SHA3([]byte("StateRoot"))
+	assert.EqualError(t, importState(chunks), "Snapshot State Root is not matching the block 10")
+}
@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+//all the changes of the state done by a block
+func includeBlockCompleteState(blkComplete *block_complete.BlockComplete, dataStorage *data_storage.DataStorage) (supply uint64, err error) {
+
+	//increase supply
+	var ast *asset.Asset
+	if ast, err = dataStorage.Asts.GetAsset(config_coins.NATIVE_ASSET_FULL); err != nil {
+		return
+	}
+
+	var reward uint64
+	if reward, _, err = blockchain_types.ComputeBlockReward(blkComplete.Height, blkComplete.Txs); err != nil {
+		return
+	}
+
+	if err = ast.AddNativeSupply(true, reward); err != nil {
+		return
+	}
+	if err = dataStorage.Asts.Update(string(config_coins.NATIVE_ASSET_FULL), ast); err != nil {
+		return
+	}
+	supply = ast.Supply
+
+	if err = blkComplete.IncludeBlockComplete(dataStorage); err != nil {
+		return 0, fmt.Errorf("Error including block %d into Blockchain: %s", blkComplete.Height, err.Error())
+	}
+
+	if err = dataStorage.ProcessPendingStakes(blkComplete.Height); err != nil {
+		return 0, errors.New("Error Processing Pending Stakes: " + err.Error())
+	}
+
+	return
+}
+
+//the block is included without storing anything to compute the state root that will be committed in the header
+func (chain *Blockchain) ComputeBlockStateRoot(blkComplete *block_complete.BlockComplete) (stateRoot []byte, err error) {
+
+	err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(reader)
+
+		if _, err = includeBlockCompleteState(blkComplete, dataStorage); err != nil {
+			return
+		}
+		if err = dataStorage.CommitChanges(); err != nil {
+			return
+		}
+
+		stateRoot, err = dataStorage.ComputeStateRoot()
+		return
+	})
+
+	return
+}
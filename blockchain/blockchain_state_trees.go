@@ -0,0 +1,29 @@
+package blockchain
+
+import (
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+//the stores created before the state trees don't have them, so they are built once from the stored state
+func (chain *Blockchain) buildStateTrees() error {
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if writer.Get("stateTrees") != nil {
+			return
+		}
+
+		gui.GUI.Info("Building the state trees...")
+
+		dataStorage := data_storage.NewDataStorage(writer)
+		if err = dataStorage.BuildStateTrees(); err != nil {
+			return
+		}
+
+		writer.Put("stateTrees", []byte{1})
+		gui.GUI.Info("Building the state trees finished")
+		return
+	})
+}
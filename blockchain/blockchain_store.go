@@ -201,6 +201,9 @@ func (chain *Blockchain) saveBlockchainHashmaps(dataStorage *data_storage.DataSt
 		if err = saveAssetsInfo(dataStorage.Asts); err != nil {
 			return
 		}
+		if err = saveAssetsHolders(dataStorage.Asts.Tx, dataStorage.AccsCollection); err != nil {
+			return
+		}
 	}
 
 	return
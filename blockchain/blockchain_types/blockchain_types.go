@@ -26,6 +26,17 @@ type BlockchainTransactionUpdate struct {
 	Keys           []*BlockchainTransactionKeyUpdate
 }
 
+type BlockchainForgerUpdate struct {
+	Forger      []byte
+	BlockHash   []byte
+	Inserted    bool
+	BlockHeight uint64
+	BlocksCount uint64
+	Reward      uint64
+	Fees        uint64
+	Commission  uint64
+}
+
 type MempoolTransactionUpdate struct {
 	Inserted                         bool
 	Tx                               *transaction.Transaction
@@ -25,6 +25,7 @@ type BlockchainUpdate struct {
 	newChainData           *BlockchainData
 	dataStorage            *data_storage.DataStorage
 	allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate
+	allForgersChanges      []*blockchain_types.BlockchainForgerUpdate
 	removedTxHashes        map[string][]byte
 	removedTxsList         [][]byte //ordered kept
 	insertedTxs            map[string]*transaction.Transaction
@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"bytes"
+	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/helpers"
 	"pandora-pay/recovery"
@@ -17,6 +18,9 @@ func (queue *BlockchainUpdatesQueue) processBlockchainUpdateNotifications() {
 			update := <-updatesNotificationsCn
 
 			queue.chain.UpdateSocketsSubscriptionsNotifications.Broadcast(update.dataStorage)
+			if len(update.allForgersChanges) > 0 {
+				queue.chain.UpdateSocketsSubscriptionsForgers.Broadcast(update.allForgersChanges)
+			}
 		}
 
 	})
@@ -46,6 +50,15 @@ func (queue *BlockchainUpdatesQueue) processBlockchainUpdateMempool() {
 				queue.chain.mempool.RemoveInsertedTxsFromBlockchain(hashes)
 			}
 
+			//let's evict the transactions of the assets that got paused
+			pausedAssets := make(map[string]bool)
+			for k, v := range update.dataStorage.Asts.Committed {
+				if v.Stored == "update" && v.Element.(*asset.Asset).Paused {
+					pausedAssets[k] = true
+				}
+			}
+			queue.chain.mempool.RemovePausedAssetsTxs(pausedAssets)
+
 			//let's add the transactions in the mempool
 			if len(update.removedTxsList) > 0 {
 
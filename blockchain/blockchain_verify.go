@@ -0,0 +1,305 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_reward"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//the chain data of the given height. It is used to know the transactions count before the first stored block
+func (chain *Blockchain) loadChainDataByHeight(reader store_db_interface.StoreDBTransactionInterface, height uint64) (*BlockchainData, error) {
+	if height == 0 {
+		return &BlockchainData{}, nil
+	}
+	chainData := &BlockchainData{}
+	if err := chainData.loadBlockchainInfo(reader, height); err != nil {
+		return nil, err
+	}
+	return chainData, nil
+}
+
+func (chain *Blockchain) loadBlockComplete(reader store_db_interface.StoreDBTransactionInterface, height uint64) (*block_complete.BlockComplete, error) {
+
+	data, err := chain.loadBlockCompleteSerialized(reader, height)
+	if err != nil {
+		return nil, err
+	}
+
+	blkComplete := block_complete.CreateEmptyBlockComplete()
+	if err = blkComplete.Deserialize(helpers.NewBufferReader(data)); err != nil {
+		return nil, err
+	}
+	if err = blkComplete.BloomAll(); err != nil {
+		return nil, err
+	}
+
+	return blkComplete, nil
+}
+
+//walks the blocks, the txs, the wallet nodes info indexes and the hash maps of the chain store and returns the mismatches found
+func (chain *Blockchain) VerifyStore() (mismatches []string, err error) {
+
+	report := func(format string, args ...interface{}) {
+		mismatches = append(mismatches, fmt.Sprintf(format, args...))
+	}
+
+	err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("blockchainInfo")
+		if data == nil {
+			return errors.New("Chain not found")
+		}
+		chainData := &BlockchainData{}
+		if err = msgpack.Unmarshal(data, chainData); err != nil {
+			return
+		}
+
+		if height, _ := binary.Uvarint(reader.Get("chainHeight")); height != chainData.Height {
+			report("chainHeight is %d but the chain height is %d", height, chainData.Height)
+		}
+		if chainData.Height > 0 && !bytes.Equal(reader.Get("chainHash"), chainData.Hash) {
+			report("chainHash is not matching the chain hash")
+		}
+		if reader.Exists("blockHash_ByHeight" + strconv.FormatUint(chainData.Height, 10)) {
+			report("block %d is stored above the chain height", chainData.Height)
+		}
+		if reader.Exists("reindexInfo") {
+			report("the wallet nodes info reindex was interrupted and it is not finished")
+		}
+
+		headersStart := chain.loadSnapshotHeight(reader)
+		start := chain.LoadPrunedHeight(reader)
+
+		startChainData, err := chain.loadChainDataByHeight(reader, start)
+		if err != nil {
+			return
+		}
+
+		txsCount := startChainData.TransactionsCount
+		addrTxsCount := make(map[string]uint64)
+
+		for height := headersStart; height < chainData.Height; height++ {
+
+			heightStr := strconv.FormatUint(height, 10)
+
+			hash := reader.Get("blockHash_ByHeight" + heightStr)
+			if hash == nil {
+				report("blockHash_ByHeight%d is missing", height)
+				continue
+			}
+			if string(reader.Get("blockHeight_ByHash"+string(hash))) != heightStr {
+				report("blockHeight_ByHash of block %d is not matching", height)
+			}
+			if !reader.Exists("block_ByHash" + string(hash)) {
+				report("block %d is missing", height)
+			}
+			if height == chainData.Height-1 && !bytes.Equal(hash, chainData.Hash) {
+				report("the last block hash is not matching the chain hash")
+			}
+
+			if height < start {
+				continue
+			}
+
+			data = reader.Get("blockTxs" + heightStr)
+			if data == nil {
+				report("blockTxs%d is missing", height)
+				continue
+			}
+			txHashes := [][]byte{}
+			if err = msgpack.Unmarshal(data, &txHashes); err != nil {
+				return
+			}
+
+			if config.SEED_WALLET_NODES_INFO && !reader.Exists("blockInfo_ByHash"+string(hash)) {
+				report("blockInfo_ByHash of block %d is missing", height)
+			}
+
+			for _, txHash := range txHashes {
+
+				txHashStr := string(txHash)
+				txId := base64.StdEncoding.EncodeToString(txHash)
+
+				if !reader.Exists("tx:"+txHashStr) || !reader.Exists("txHash:"+txHashStr) {
+					report("tx %s of block %d is missing", txId, height)
+				}
+				if txBlock, _ := binary.Uvarint(reader.Get("txBlock:" + txHashStr)); txBlock != height {
+					report("txBlock of tx %s is %d instead of %d", txId, txBlock, height)
+				}
+
+				if config.SEED_WALLET_NODES_INFO {
+
+					if !bytes.Equal(reader.Get("txHash_ByHeight"+strconv.FormatUint(txsCount, 10)), txHash) {
+						report("txHash_ByHeight%d is not matching tx %s", txsCount, txId)
+					}
+					if !reader.Exists("txInfo_ByHash"+txHashStr) || !reader.Exists("txPreview_ByHash"+txHashStr) {
+						report("txInfo of tx %s is missing", txId)
+					}
+
+					keys := make([][]byte, 0)
+					if data = reader.Get("txKeys:" + txHashStr); data == nil {
+						report("txKeys of tx %s is missing", txId)
+					} else if err = msgpack.Unmarshal(data, &keys); err != nil {
+						return
+					}
+
+					for _, key := range keys {
+						count := addrTxsCount[string(key)]
+						if !bytes.Equal(reader.Get("addrTx:"+string(key)+":"+strconv.FormatUint(count, 10)), txHash) {
+							report("addrTx %d of %s is not matching tx %s", count, base64.StdEncoding.EncodeToString(key), txId)
+						}
+						addrTxsCount[string(key)] = count + 1
+					}
+				}
+
+				txsCount += 1
+			}
+		}
+
+		if txsCount != chainData.TransactionsCount {
+			report("the chain has %d txs but %d were found in the blocks", chainData.TransactionsCount, txsCount)
+		}
+
+		if config.SEED_WALLET_NODES_INFO {
+
+			if reader.Exists("txHash_ByHeight" + strconv.FormatUint(txsCount, 10)) {
+				report("txHash_ByHeight%d is stored above the txs count", txsCount)
+			}
+
+			//only the addresses of the txs after the snapshot are indexed
+			if err = reader.Iterate("addrTxsCount:", "", false, func(key string, value []byte) bool {
+				key = key[len("addrTxsCount:"):]
+				if count, err2 := strconv.ParseUint(string(value), 10, 64); err2 != nil || count != addrTxsCount[key] {
+					report("addrTxsCount of %s is %s instead of %d", base64.StdEncoding.EncodeToString([]byte(key)), string(value), addrTxsCount[key])
+				}
+				delete(addrTxsCount, key)
+				return true
+			}); err != nil {
+				return
+			}
+			for key, count := range addrTxsCount {
+				report("addrTxsCount of %s is missing instead of %d", base64.StdEncoding.EncodeToString([]byte(key)), count)
+			}
+		}
+
+		dataStorage := data_storage.NewDataStorage(reader)
+
+		verifyHashMap := func(hashMap *hash_map.HashMap) (count uint64, err error) {
+			var list []string
+			if count, list, err = hashMap.Verify(); err != nil {
+				return
+			}
+			mismatches = append(mismatches, list...)
+			return
+		}
+
+		var accountsCount, assetsCount uint64
+		if accountsCount, err = verifyHashMap(dataStorage.PlainAccs.HashMap); err != nil {
+			return
+		}
+		if accountsCount != chainData.AccountsCount {
+			report("the chain has %d accounts but %d exist", chainData.AccountsCount, accountsCount)
+		}
+
+		if assetsCount, err = verifyHashMap(dataStorage.Asts.HashMap); err != nil {
+			return
+		}
+		if assetsCount != chainData.AssetsCount {
+			report("the chain has %d assets but %d exist", chainData.AssetsCount, assetsCount)
+		}
+
+		if _, err = verifyHashMap(dataStorage.PendingStakes.HashMap); err != nil {
+			return
+		}
+
+		assetsIds := [][]byte{}
+		if err = reader.Iterate("assets:exists:", "", false, func(key string, value []byte) bool {
+			assetsIds = append(assetsIds, []byte(key[len("assets:exists:"):]))
+			return true
+		}); err != nil {
+			return
+		}
+		for _, assetId := range assetsIds {
+			var accs *accounts.Accounts
+			if accs, err = dataStorage.AccsCollection.GetMap(assetId); err != nil {
+				return
+			}
+			if _, err = verifyHashMap(accs.HashMap); err != nil {
+				return
+			}
+		}
+
+		ast, err := dataStorage.Asts.GetAsset(config_coins.NATIVE_ASSET_FULL)
+		if err != nil {
+			return
+		}
+		if ast == nil {
+			report("the native asset is missing")
+		} else {
+			//the chain supply is read before the blocks without a state root add the reward of the block to the native asset a second time
+			supply := chainData.Supply
+			if chainData.Height > 0 && block.GetBlockVersion(chainData.Height-1) < block.BLOCK_VERSION_STATE_ROOT {
+				if err = helpers.SafeUint64Add(&supply, config_reward.GetRewardAt(chainData.Height-1)); err != nil {
+					return
+				}
+			}
+			if supply != ast.Supply {
+				report("the native asset supply is %d instead of %d", ast.Supply, supply)
+			}
+		}
+
+		return
+	})
+
+	return
+}
+
+//verifies the chain store, prints the mismatches and optionally rebuilds the indexes
+func (chain *Blockchain) verifyStoreAndReport(rebuild bool) (err error) {
+
+	gui.GUI.Info("Verifying the chain store...")
+
+	mismatches, err := chain.VerifyStore()
+	if err != nil {
+		return
+	}
+
+	for _, mismatch := range mismatches {
+		gui.GUI.Error("Store mismatch:", mismatch)
+	}
+	gui.GUI.Info("Store verified.", len(mismatches), "mismatches found")
+
+	if !rebuild {
+		return
+	}
+
+	if err = chain.ReindexInfo(); err != nil {
+		return
+	}
+
+	if mismatches, err = chain.VerifyStore(); err != nil {
+		return
+	}
+	for _, mismatch := range mismatches {
+		gui.GUI.Error("Store mismatch:", mismatch)
+	}
+	gui.GUI.Info("Indexes rebuilt.", len(mismatches), "mismatches remaining")
+
+	return
+}
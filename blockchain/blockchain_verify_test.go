@@ -0,0 +1,150 @@
+package blockchain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"math"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_reward"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+//a chain of one block without txs, two plain accounts and the native asset
+func createVerifyTestStore(t *testing.T, assetSupply uint64, corrupt func(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, plainAccs [][]byte)) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", DB: db}
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		if err = dataStorage.Asts.CreateAsset(config_coins.NATIVE_ASSET_FULL, &asset.Asset{
+			PublicKeyHash:    config_coins.NATIVE_ASSET_FULL,
+			DecimalSeparator: byte(config_coins.DECIMAL_SEPARATOR),
+			MaxSupply:        config_coins.MAX_SUPPLY_COINS_UNITS,
+			Supply:           assetSupply,
+			UpdatePublicKey:  config_coins.BURN_PUBLIC_KEY,
+			SupplyPublicKey:  config_coins.BURN_PUBLIC_KEY,
+			Name:             config_coins.NATIVE_ASSET_NAME,
+			Ticker:           config_coins.NATIVE_ASSET_TICKER,
+			Identification:   config_coins.NATIVE_ASSET_IDENTIFICATION,
+			Description:      config_coins.NATIVE_ASSET_DESCRIPTION,
+		}); err != nil {
+			return
+		}
+
+		plainAccs := make([][]byte, 2)
+		for i := range plainAccs {
+			plainAccs[i] = helpers.RandomBytes(cryptography.PublicKeyHashSize)
+
+			var plainAcc *plain_account.PlainAccount
+			if plainAcc, err = dataStorage.CreatePlainAccount(plainAccs[i]); err != nil {
+				return
+			}
+			if err = plainAcc.IncrementNonce(true); err != nil {
+				return
+			}
+			if err = dataStorage.PlainAccs.Update(string(plainAccs[i]), plainAcc); err != nil {
+				return
+			}
+		}
+
+		if err = dataStorage.CommitChanges(); err != nil {
+			return
+		}
+
+		hash := cryptography.SHA3([]byte("Block0"))
+		writer.Put("blockHash_ByHeight0", hash)
+		writer.Put("blockHeight_ByHash"+string(hash), []byte("0"))
+		writer.Put("block_ByHash"+string(hash), []byte{0})
+
+		var data []byte
+		if data, err = msgpack.Marshal([][]byte{}); err != nil {
+			return
+		}
+		writer.Put("blockTxs0", data)
+
+		chainData := &BlockchainData{
+			Height:        1,
+			Hash:          hash,
+			Supply:        1000,
+			AccountsCount: 2,
+			AssetsCount:   1,
+		}
+
+		if corrupt != nil {
+			corrupt(writer, chainData, plainAccs)
+		}
+
+		chainData.saveBlockchainHeight(writer)
+		return chainData.saveBlockchain(writer)
+	}))
+}
+
+func TestBlockchain_VerifyStore(t *testing.T) {
+
+	defer func(storeBlockchain *store.Store, height uint64, seed bool) {
+		store.StoreBlockchain = storeBlockchain
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+		config.SEED_WALLET_NODES_INFO = seed
+	}(store.StoreBlockchain, config.BLOCK_STATE_ROOT_HEIGHT, config.SEED_WALLET_NODES_INFO)
+	config.BLOCK_STATE_ROOT_HEIGHT = 0
+	config.SEED_WALLET_NODES_INFO = false
+
+	chain := &Blockchain{}
+
+	verify := func(assetSupply uint64, corrupt func(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, plainAccs [][]byte)) []string {
+		createVerifyTestStore(t, assetSupply, corrupt)
+		mismatches, err := chain.VerifyStore()
+		assert.NoError(t, err)
+		return mismatches
+	}
+
+	assert.Empty(t, verify(1000, nil))
+
+	//the native asset supply is not matching the chain supply
+	assert.Equal(t, []string{"the native asset supply is 999 instead of 1000"}, verify(999, nil))
+
+	//the blocks without a state root added the reward of the last block to the native asset a second time
+	config.BLOCK_STATE_ROOT_HEIGHT = math.MaxUint64
+	reward := config_reward.GetRewardAt(0)
+	assert.Empty(t, verify(1000+reward, nil))
+	assert.Equal(t, []string{fmt.Sprintf("the native asset supply is 1000 instead of %d", 1000+reward)}, verify(1000, nil))
+	//an asset supply smaller than the reward is reported instead of wrapping around
+	assert.Equal(t, []string{fmt.Sprintf("the native asset supply is 0 instead of %d", 1000+reward)}, verify(0, nil))
+	config.BLOCK_STATE_ROOT_HEIGHT = 0
+
+	mismatches := verify(1000, func(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, plainAccs [][]byte) {
+		writer.Delete("blockTxs0")
+	})
+	assert.Equal(t, []string{"blockTxs0 is missing"}, mismatches)
+
+	mismatches = verify(1000, func(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, plainAccs [][]byte) {
+		writer.Delete("block_ByHash" + string(chainData.Hash))
+	})
+	assert.Equal(t, []string{"block 0 is missing"}, mismatches)
+
+	mismatches = verify(1000, func(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, plainAccs [][]byte) {
+		chainData.TransactionsCount = 5
+	})
+	assert.Equal(t, []string{"the chain has 5 txs but 0 were found in the blocks"}, mismatches)
+
+	//the plain account is not counted and its data is left without the key
+	mismatches = verify(1000, func(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, plainAccs [][]byte) {
+		writer.Delete("plainAccs:exists:" + string(plainAccs[0]))
+	})
+	assert.Equal(t, 3, len(mismatches), mismatches)
+	assert.Contains(t, mismatches, "the chain has 2 accounts but 1 exist")
+}
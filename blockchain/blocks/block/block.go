@@ -17,6 +17,7 @@ import (
 type Block struct {
 	*BlockHeader
 	MerkleHash              []byte      `json:"merkleHash" msgpack:"merkleHash"`          //32 byte
+	StateRoot               []byte      `json:"stateRoot" msgpack:"stateRoot"`            //32 byte only for BLOCK_VERSION_STATE_ROOT
 	PrevHash                []byte      `json:"prevHash"  msgpack:"prevHash"`             //32 byte
 	PrevKernelHash          []byte      `json:"prevKernelHash"  msgpack:"prevKernelHash"` //32 byte
 	Timestamp               uint64      `json:"timestamp" msgpack:"timestamp"`
@@ -40,6 +41,12 @@ func (blk *Block) validate() error {
 		return err
 	}
 
+	if blk.HasStateRoot() && len(blk.StateRoot) != cryptography.HashSize {
+		return errors.New("StateRoot is invalid")
+	}
+	if !blk.HasStateRoot() && len(blk.StateRoot) != 0 {
+		return errors.New("blk.StateRoot must be nil")
+	}
 	if len(blk.Forger) != cryptography.PublicKeyHashSize {
 		return errors.New("Forger is invalid")
 	}
@@ -66,6 +73,16 @@ func (blk *Block) Verify() error {
 	return blk.Bloom.verifyIfBloomed()
 }
 
+//commission paid to the RewardCollector out of the block reward including the fees
+func (blk *Block) ComputeCommission(final uint64) (commission uint64, err error) {
+	commission = final
+	if err = helpers.SafeUint64Mul(&commission, blk.DelegatedStakeFee); err != nil {
+		return
+	}
+	commission /= config_stake.DELEGATING_STAKING_FEE_MAX_VALUE
+	return
+}
+
 func (blk *Block) IncludeBlock(dataStorage *data_storage.DataStorage, allFees uint64) (err error) {
 
 	if blk.StakingAmount < config_stake.GetRequiredStake(blk.Height) {
@@ -97,12 +114,10 @@ func (blk *Block) IncludeBlock(dataStorage *data_storage.DataStorage, allFees ui
 
 	if blk.DelegatedStakeFee > 0 {
 
-		//compute the commission
-		commission := final
-		if err = helpers.SafeUint64Mul(&commission, blk.DelegatedStakeFee); err != nil {
+		var commission uint64
+		if commission, err = blk.ComputeCommission(final); err != nil {
 			return
 		}
-		commission /= config_stake.DELEGATING_STAKING_FEE_MAX_VALUE
 
 		if err = helpers.SafeUint64Sub(&final, commission); err != nil {
 			return
@@ -119,6 +134,11 @@ func (blk *Block) IncludeBlock(dataStorage *data_storage.DataStorage, allFees ui
 		return
 	}
 
+	//consensus rule: the reward was already added to the supply before the block was included. The version 0 blocks add it a second time, so they keep their original state
+	if blk.HasStateRoot() {
+		return
+	}
+
 	var ast *asset.Asset
 	if ast, err = dataStorage.Asts.GetAsset(config_coins.NATIVE_ASSET_FULL); err != nil {
 		return
@@ -134,6 +154,24 @@ func (blk *Block) IncludeBlock(dataStorage *data_storage.DataStorage, allFees ui
 	return
 }
 
+//the state root committed in the header must match the state after the block was included
+func (blk *Block) VerifyStateRoot(dataStorage *data_storage.DataStorage) error {
+
+	if !blk.HasStateRoot() {
+		return nil
+	}
+
+	stateRoot, err := dataStorage.ComputeStateRoot()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(blk.StateRoot, stateRoot) {
+		return fmt.Errorf("Block %d State Root is not matching", blk.Height)
+	}
+
+	return nil
+}
+
 func (blk *Block) computeHash() []byte {
 	return cryptography.SHA3(helpers.SerializeToBytes(blk))
 }
@@ -156,6 +194,9 @@ func (blk *Block) AdvancedSerialization(w *helpers.BufferWriter, kernelHash bool
 
 	if !kernelHash {
 		w.Write(blk.MerkleHash)
+		if blk.HasStateRoot() {
+			w.Write(blk.StateRoot)
+		}
 		w.Write(blk.PrevHash)
 	}
 
@@ -207,6 +248,11 @@ func (blk *Block) Deserialize(r *helpers.BufferReader) (err error) {
 	if blk.MerkleHash, err = r.ReadHash(); err != nil {
 		return
 	}
+	if blk.HasStateRoot() {
+		if blk.StateRoot, err = r.ReadHash(); err != nil {
+			return
+		}
+	}
 	if blk.PrevHash, err = r.ReadHash(); err != nil {
 		return
 	}
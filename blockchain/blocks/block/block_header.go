@@ -2,18 +2,35 @@ package block
 
 import (
 	"errors"
+	"pandora-pay/config"
 	"pandora-pay/helpers"
 )
 
+const (
+	BLOCK_VERSION_INITIAL    uint64 = 0
+	BLOCK_VERSION_STATE_ROOT uint64 = 1 //the header commits the state root
+)
+
 type BlockHeader struct {
 	helpers.SerializableInterface `json:"-" msgpack:"-"`
 	Version                       uint64 `json:"version" msgpack:"version"`
 	Height                        uint64 `json:"height" msgpack:"height"`
 }
 
+func GetBlockVersion(height uint64) uint64 {
+	if height >= config.BLOCK_STATE_ROOT_HEIGHT {
+		return BLOCK_VERSION_STATE_ROOT
+	}
+	return BLOCK_VERSION_INITIAL
+}
+
+func (blockHeader *BlockHeader) HasStateRoot() bool {
+	return blockHeader.Version >= BLOCK_VERSION_STATE_ROOT
+}
+
 func (blockHeader *BlockHeader) validate() error {
-	if blockHeader.Version != 0 {
-		return errors.New("Invalid Block")
+	if blockHeader.Version != GetBlockVersion(blockHeader.Height) {
+		return errors.New("Invalid Block Version")
 	}
 	return nil
 }
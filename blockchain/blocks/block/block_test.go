@@ -3,8 +3,17 @@ package block
 import (
 	"github.com/stretchr/testify/assert"
 	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_reward"
+	"pandora-pay/config/config_stake"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
 	"testing"
 	"time"
 )
@@ -18,16 +27,7 @@ var (
 func TestBlock_Serialize(t *testing.T) {
 	var err error
 
-	privateKey := addresses.GenerateNewPrivateKey()
-	publicKey := privateKey.GeneratePublicKey()
-
-	blk := Block{
-		BlockHeader:    &BlockHeader{Version: 0, Height: 0},
-		MerkleHash:     merkleHash,
-		PrevHash:       prevHash,
-		PrevKernelHash: prevKernelHash,
-		Timestamp:      uint64(time.Now().Unix()),
-	}
+	blk := createTestBlock(0)
 
 	buf := blk.SerializeManualToBytes()
 	assert.Equal(t, len(buf) < 30, false, "Invalid serialization")
@@ -47,7 +47,6 @@ func TestBlock_SerializeForSigning(t *testing.T) {
 	var err error
 
 	privateKey := addresses.GenerateNewPrivateKey()
-	publicKey := privateKey.GeneratePublicKey()
 
 	blockHeader := &BlockHeader{Version: 0, Height: 0}
 	blk := Block{
@@ -67,3 +66,168 @@ func TestBlock_SerializeForSigning(t *testing.T) {
 
 	assert.NotEqual(t, signature, helpers.EmptyBytes(cryptography.SignatureSize), "Invalid signature")
 }
+
+func createTestBlock(height uint64) *Block {
+	blk := &Block{
+		BlockHeader:             &BlockHeader{Version: GetBlockVersion(height), Height: height},
+		MerkleHash:              merkleHash,
+		PrevHash:                prevHash,
+		PrevKernelHash:          prevKernelHash,
+		Timestamp:               uint64(time.Now().Unix()),
+		Forger:                  helpers.RandomBytes(cryptography.PublicKeyHashSize),
+		DelegatedStakePublicKey: helpers.RandomBytes(cryptography.PublicKeySize),
+		Signature:               helpers.RandomBytes(cryptography.SignatureSize),
+	}
+	if blk.HasStateRoot() {
+		blk.StateRoot = cryptography.SHA3([]byte("StateRoot"))
+	}
+	return blk
+}
+
+func TestBlock_StateRootActivation(t *testing.T) {
+
+	defer func(height uint64) {
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+	}(config.BLOCK_STATE_ROOT_HEIGHT)
+	config.BLOCK_STATE_ROOT_HEIGHT = 10
+
+	for _, height := range []uint64{0, 9, 10, 11} {
+
+		blk := createTestBlock(height)
+		assert.Equal(t, height >= 10, blk.HasStateRoot())
+		assert.NoError(t, blk.validate())
+
+		buf := blk.SerializeManualToBytes()
+		blk2 := CreateEmptyBlock()
+		assert.NoError(t, blk2.Deserialize(helpers.NewBufferReader(buf)))
+		assert.Equal(t, blk.Version, blk2.Version)
+		assert.Equal(t, blk.StateRoot, blk2.StateRoot)
+		assert.Equal(t, buf, blk2.SerializeManualToBytes())
+
+		//the state root is part of the hash only starting with the activation height
+		hash := cryptography.SHA3(buf)
+		blk.StateRoot = cryptography.SHA3([]byte("OtherStateRoot"))
+		assert.Equal(t, height >= 10, !assert.ObjectsAreEqual(hash, cryptography.SHA3(blk.SerializeManualToBytes())))
+
+		//the version must be the one of the height
+		if blk.HasStateRoot() {
+			blk.Version = BLOCK_VERSION_INITIAL
+		} else {
+			blk.Version = BLOCK_VERSION_STATE_ROOT
+		}
+		assert.Error(t, blk.validate())
+	}
+
+	//an old version block can not have a state root
+	blk := createTestBlock(9)
+	blk.StateRoot = cryptography.SHA3([]byte("StateRoot"))
+	assert.Error(t, blk.validate())
+}
+
+func TestBlock_VerifyStateRoot(t *testing.T) {
+
+	defer func(height uint64) {
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+	}(config.BLOCK_STATE_ROOT_HEIGHT)
+	config.BLOCK_STATE_ROOT_HEIGHT = 10
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(dbTx)
+
+		publicKeyHash := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+		if err = dataStorage.AddStakePendingStake(publicKeyHash, 10, true, 0); err != nil {
+			return
+		}
+		if err = dataStorage.CommitChanges(); err != nil {
+			return
+		}
+
+		var stateRoot []byte
+		if stateRoot, err = dataStorage.ComputeStateRoot(); err != nil {
+			return
+		}
+
+		blk := createTestBlock(10)
+		blk.StateRoot = stateRoot
+		assert.NoError(t, blk.VerifyStateRoot(dataStorage))
+
+		blk.StateRoot = cryptography.SHA3([]byte("StateRoot"))
+		assert.EqualError(t, blk.VerifyStateRoot(dataStorage), "Block 10 State Root is not matching")
+
+		//the old version blocks don't commit the state root
+		assert.NoError(t, createTestBlock(9).VerifyStateRoot(dataStorage))
+		return
+	})
+	assert.NoError(t, err)
+}
+
+func TestBlock_IncludeBlockSupply(t *testing.T) {
+
+	defer func(height uint64) {
+		config.BLOCK_STATE_ROOT_HEIGHT = height
+	}(config.BLOCK_STATE_ROOT_HEIGHT)
+	config.BLOCK_STATE_ROOT_HEIGHT = 10
+
+	for _, height := range []uint64{9, 10} {
+
+		db, err := store_db_memory.CreateStoreDBMemory("")
+		assert.NoError(t, err)
+
+		err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			dataStorage := data_storage.NewDataStorage(dbTx)
+
+			if err = dataStorage.Asts.CreateAsset(config_coins.NATIVE_ASSET_FULL, &asset.Asset{
+				PublicKeyHash:    config_coins.NATIVE_ASSET_FULL,
+				DecimalSeparator: byte(config_coins.DECIMAL_SEPARATOR),
+				MaxSupply:        config_coins.MAX_SUPPLY_COINS_UNITS,
+				Supply:           1000,
+				UpdatePublicKey:  config_coins.BURN_PUBLIC_KEY,
+				SupplyPublicKey:  config_coins.BURN_PUBLIC_KEY,
+				Name:             config_coins.NATIVE_ASSET_NAME,
+				Ticker:           config_coins.NATIVE_ASSET_TICKER,
+				Identification:   config_coins.NATIVE_ASSET_IDENTIFICATION,
+				Description:      config_coins.NATIVE_ASSET_DESCRIPTION,
+			}); err != nil {
+				return
+			}
+
+			blk := createTestBlock(height)
+			blk.StakingAmount = config_stake.GetRequiredStake(height)
+
+			var plainAcc *plain_account.PlainAccount
+			if plainAcc, err = dataStorage.CreatePlainAccount(blk.Forger); err != nil {
+				return
+			}
+			if err = plainAcc.AddStakeAvailable(true, blk.StakingAmount); err != nil {
+				return
+			}
+			if err = plainAcc.IncrementNonce(true); err != nil {
+				return
+			}
+			if err = dataStorage.PlainAccs.Update(string(blk.Forger), plainAcc); err != nil {
+				return
+			}
+
+			assert.NoError(t, blk.IncludeBlock(dataStorage, 0))
+
+			var ast *asset.Asset
+			if ast, err = dataStorage.Asts.GetAsset(config_coins.NATIVE_ASSET_FULL); err != nil {
+				return
+			}
+
+			//the blocks without a state root add the reward to the supply a second time
+			if blk.HasStateRoot() {
+				assert.Equal(t, uint64(1000), ast.Supply)
+			} else {
+				assert.Equal(t, 1000+config_reward.GetRewardAt(height), ast.Supply)
+			}
+			return
+		})
+		assert.NoError(t, err)
+	}
+}
@@ -5,18 +5,21 @@ import (
 	"pandora-pay/config/config_coins"
 	"pandora-pay/helpers"
 	"pandora-pay/store/hash_map"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
 
 type AccountsCollection struct {
-	tx       store_db_interface.StoreDBTransactionInterface
-	accsMap  map[string]*Accounts
-	listMaps []*hash_map.HashMap
+	tx        store_db_interface.StoreDBTransactionInterface
+	accsMap   map[string]*Accounts
+	listMaps  []*hash_map.HashMap
+	StateTree *state_tree.StateTree //shared by the accounts of all assets
 }
 
 func (collection *AccountsCollection) SetTx(tx store_db_interface.StoreDBTransactionInterface) {
 	collection.tx = tx
+	collection.StateTree.SetTx(tx)
 }
 
 func (collection *AccountsCollection) GetAllMaps() map[string]*Accounts {
@@ -39,6 +42,8 @@ func (collection *AccountsCollection) GetMap(assetId []byte) (*Accounts, error)
 		if accs, err = NewAccounts(collection.tx, assetId); err != nil {
 			return nil, err
 		}
+		accs.HashMap.StateTree = collection.StateTree
+		accs.HashMap.StateTreeKey = helpers.CloneBytes(assetId)
 		collection.listMaps = append(collection.listMaps, accs.HashMap)
 		collection.accsMap[string(assetId)] = accs
 	}
@@ -91,5 +96,6 @@ func NewAccountsCollection(tx store_db_interface.StoreDBTransactionInterface) *A
 		tx,
 		make(map[string]*Accounts),
 		make([]*hash_map.HashMap, 0),
+		state_tree.CreateNewStateTree(tx, "accounts:stateTree"),
 	}
 }
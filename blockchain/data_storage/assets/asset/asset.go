@@ -41,6 +41,12 @@ type Asset struct {
 	Identification                               string `json:"identification" msgpack:"identification"`
 	Description                                  string `json:"description,omitempty" msgpack:"description,omitempty"`
 	Data                                         []byte `json:"data,omitempty" msgpack:"data,omitempty"`
+	Paused                                       bool   `json:"paused,omitempty" msgpack:"paused,omitempty"` //transactions are suspended
+	Frozen                                       bool   `json:"frozen,omitempty" msgpack:"frozen,omitempty"` //supply can not be changed anymore
+}
+
+func GenerateAssetId(txHash []byte) []byte {
+	return cryptography.RIPEMD(txHash)
 }
 
 func (asset *Asset) IsDeletable() bool {
@@ -62,7 +68,7 @@ func (asset *Asset) GetIndex() uint64 {
 	return asset.Index
 }
 
-func (asset *Asset) Validate() error {
+func (asset *Asset) ValidateParams() error {
 	if asset.DecimalSeparator > config_assets.ASSETS_DECIMAL_SEPARATOR_MAX_BYTE {
 		return errors.New("asset decimal separator is invalid")
 	}
@@ -90,6 +96,32 @@ func (asset *Asset) Validate() error {
 		return errors.New("Asset description is invalid")
 	}
 
+	if len(asset.UpdatePublicKey) != cryptography.PublicKeySize {
+		return errors.New("Asset update public key is invalid")
+	}
+	if len(asset.SupplyPublicKey) != cryptography.PublicKeySize {
+		return errors.New("Asset supply public key is invalid")
+	}
+	if asset.Supply > asset.MaxSupply {
+		return errors.New("Asset supply exceeded max supply")
+	}
+
+	if asset.Paused && !asset.CanPause {
+		return errors.New("Asset can not be paused")
+	}
+	if asset.Frozen && !asset.CanFreeze {
+		return errors.New("Asset can not be frozen")
+	}
+
+	return nil
+}
+
+func (asset *Asset) Validate() error {
+
+	if err := asset.ValidateParams(); err != nil {
+		return err
+	}
+
 	if len(asset.PublicKeyHash) != cryptography.PublicKeyHashSize {
 		return errors.New("Asset Public key is invalid")
 	}
@@ -153,6 +185,9 @@ func (asset *Asset) AddSupply(sign bool, amount uint64) error {
 	if bytes.Equal(asset.SupplyPublicKey, config_coins.BURN_PUBLIC_KEY) {
 		return errors.New("BURN PUBLIC KEY")
 	}
+	if asset.Frozen {
+		return errors.New("Asset supply is frozen")
+	}
 
 	if sign {
 		if !asset.CanMint {
@@ -196,12 +231,19 @@ func (asset *Asset) Serialize(w *helpers.BufferWriter) {
 	w.WriteString(asset.Ticker)
 	w.WriteString(asset.Description)
 	w.WriteVariableBytes(asset.Data)
+
+	if asset.CanPause {
+		w.WriteBool(asset.Paused)
+	}
+	if asset.CanFreeze {
+		w.WriteBool(asset.Frozen)
+	}
 }
 
 func (asset *Asset) setIdentification() {
 	if bytes.Equal(asset.PublicKeyHash, config_coins.NATIVE_ASSET_FULL) {
 		asset.Identification = config_coins.NATIVE_ASSET_IDENTIFICATION
-	} else {
+	} else if len(asset.PublicKeyHash) == cryptography.PublicKeyHashSize {
 		asset.Identification = asset.Ticker + "-" + hex.EncodeToString(asset.PublicKeyHash[:3])
 	}
 }
@@ -259,6 +301,16 @@ func (asset *Asset) Deserialize(r *helpers.BufferReader) (err error) {
 	if asset.Data, err = r.ReadVariableBytes(5120); err != nil {
 		return
 	}
+	if asset.CanPause {
+		if asset.Paused, err = r.ReadBool(); err != nil {
+			return
+		}
+	}
+	if asset.CanFreeze {
+		if asset.Frozen, err = r.ReadBool(); err != nil {
+			return
+		}
+	}
 
 	asset.setIdentification()
 
@@ -0,0 +1,41 @@
+package asset
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"testing"
+)
+
+func TestAsset_SerializePausedFrozen(t *testing.T) {
+
+	ast := &Asset{
+		PublicKeyHash:   helpers.RandomBytes(cryptography.PublicKeyHashSize),
+		CanMint:         true,
+		CanPause:        true,
+		CanFreeze:       true,
+		MaxSupply:       1000,
+		Supply:          10,
+		UpdatePublicKey: helpers.RandomBytes(cryptography.PublicKeySize),
+		SupplyPublicKey: helpers.RandomBytes(cryptography.PublicKeySize),
+		Name:            "My Asset",
+		Ticker:          "AST",
+		Description:     "My simple Asset",
+		Paused:          true,
+		Frozen:          true,
+	}
+	ast.setIdentification()
+	assert.Nil(t, ast.Validate())
+
+	ast2 := &Asset{PublicKeyHash: ast.PublicKeyHash}
+	assert.Nil(t, ast2.Deserialize(helpers.NewBufferReader(helpers.SerializeToBytes(ast))))
+	assert.Equal(t, ast2.Paused, true)
+	assert.Equal(t, ast2.Frozen, true)
+	assert.Equal(t, ast2.Identification, ast.Identification)
+
+	assert.NotNil(t, ast2.AddSupply(true, 1), "frozen supply should not change")
+
+	//without the flags the state is not serialized
+	ast.CanPause, ast.CanFreeze, ast.Paused, ast.Frozen = false, false, false, false
+	assert.Equal(t, len(helpers.SerializeToBytes(ast))+2, len(helpers.SerializeToBytes(ast2)))
+}
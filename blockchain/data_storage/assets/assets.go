@@ -5,6 +5,7 @@ import (
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/config/config_coins"
 	"pandora-pay/store/hash_map"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -38,6 +39,7 @@ func (assets *Assets) CreateAsset(key []byte, ast *asset.Asset) (err error) {
 func NewAssets(tx store_db_interface.StoreDBTransactionInterface) (assets *Assets) {
 
 	hashMap := hash_map.CreateNewHashMap(tx, "assets", config_coins.ASSET_LENGTH, true)
+	hashMap.StateTree = state_tree.CreateNewStateTree(tx, "assets:stateTree")
 
 	assets = &Assets{
 		hashMap,
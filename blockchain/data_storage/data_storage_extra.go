@@ -3,7 +3,11 @@ package data_storage
 import (
 	"errors"
 	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
 	"pandora-pay/store/hash_map"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -63,6 +67,64 @@ func (dataStorage *DataStorage) CommitChanges() (err error) {
 	return
 }
 
+//the state root commits to the plain accounts, accounts, pending stakes and assets
+func (dataStorage *DataStorage) ComputeStateRoot() ([]byte, error) {
+
+	list := dataStorage.GetList(false)
+	for _, it := range list {
+		if err := it.UpdateStateTree(); err != nil {
+			return nil, err
+		}
+	}
+
+	w := helpers.NewBufferWriter()
+	for _, tree := range []*state_tree.StateTree{
+		dataStorage.PlainAccs.StateTree,
+		dataStorage.AccsCollection.StateTree,
+		dataStorage.PendingStakes.StateTree,
+		dataStorage.Asts.StateTree,
+	} {
+		root, err := tree.GetRoot()
+		if err != nil {
+			return nil, err
+		}
+		w.Write(root)
+	}
+
+	return cryptography.SHA3(w.Bytes()), nil
+}
+
+//the state trees are built from the stored data, used by the stores created before the state trees
+func (dataStorage *DataStorage) BuildStateTrees() (err error) {
+
+	for _, it := range dataStorage.GetListWithoutCollections() {
+		if err = it.BuildStateTree(); err != nil {
+			return
+		}
+	}
+
+	//every asset has its own accounts
+	assetsIds := make([][]byte, 0)
+	if err = dataStorage.Asts.Iterate("", false, func(key string, element hash_map.HashMapElementSerializableInterface) (bool, error) {
+		assetsIds = append(assetsIds, []byte(key))
+		return true, nil
+	}); err != nil {
+		return
+	}
+
+	for _, assetId := range assetsIds {
+		var accs *accounts.Accounts
+		if accs, err = dataStorage.AccsCollection.GetMap(assetId); err != nil {
+			return
+		}
+		if err = accs.BuildStateTree(); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func (dataStorage *DataStorage) SetTx(dbTx store_db_interface.StoreDBTransactionInterface) {
 	dataStorage.DBTx = dbTx
 	list := dataStorage.GetList(false)
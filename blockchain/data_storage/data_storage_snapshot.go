@@ -0,0 +1,206 @@
+package data_storage
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
+	"pandora-pay/store/hash_map"
+)
+
+type SnapshotEntryType byte
+
+const (
+	SNAPSHOT_ENTRY_PLAIN_ACCOUNT SnapshotEntryType = iota
+	SNAPSHOT_ENTRY_ACCOUNT
+	SNAPSHOT_ENTRY_PENDING_STAKES
+	SNAPSHOT_ENTRY_ASSET
+)
+
+type snapshotWriter struct {
+	chunkSize int
+	chunks    [][]byte
+	w         *helpers.BufferWriter
+}
+
+func (writer *snapshotWriter) write(entryType SnapshotEntryType, key, value []byte) {
+
+	writer.w.WriteByte(byte(entryType))
+	writer.w.WriteVariableBytes(key)
+	writer.w.WriteVariableBytes(value)
+
+	if writer.w.Length() >= writer.chunkSize {
+		writer.flush()
+	}
+}
+
+func (writer *snapshotWriter) flush() {
+	if writer.w.Length() > 0 {
+		writer.chunks = append(writer.chunks, writer.w.Bytes())
+		writer.w = helpers.NewBufferWriter()
+	}
+}
+
+func (dataStorage *DataStorage) exportHashMap(writer *snapshotWriter, entryType SnapshotEntryType, hashMap *hash_map.HashMap) error {
+	return hashMap.StateTree.Iterate(func(key []byte) error {
+		value, err := hashMap.GetSerialized(string(key))
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			return errors.New("Snapshot element was not found")
+		}
+		writer.write(entryType, key, value)
+		return nil
+	})
+}
+
+//the state is split in chunks. The assets are exported in the order of their indexes
+func (dataStorage *DataStorage) ExportSnapshot(chunkSize int) ([][]byte, error) {
+
+	writer := &snapshotWriter{chunkSize, make([][]byte, 0), helpers.NewBufferWriter()}
+
+	if err := dataStorage.exportHashMap(writer, SNAPSHOT_ENTRY_PLAIN_ACCOUNT, dataStorage.PlainAccs.HashMap); err != nil {
+		return nil, err
+	}
+
+	if err := dataStorage.exportHashMap(writer, SNAPSHOT_ENTRY_PENDING_STAKES, dataStorage.PendingStakes.HashMap); err != nil {
+		return nil, err
+	}
+
+	if err := dataStorage.AccsCollection.StateTree.Iterate(func(key []byte) error {
+
+		if len(key) <= config_coins.ASSET_LENGTH {
+			return errors.New("Snapshot account key is invalid")
+		}
+
+		accs, err := dataStorage.AccsCollection.GetMap(key[:config_coins.ASSET_LENGTH])
+		if err != nil {
+			return err
+		}
+
+		value, err := accs.GetSerialized(string(key[config_coins.ASSET_LENGTH:]))
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			return errors.New("Snapshot account was not found")
+		}
+
+		writer.write(SNAPSHOT_ENTRY_ACCOUNT, key, value)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := uint64(0); i < dataStorage.Asts.Count; i++ {
+
+		key, err := dataStorage.Asts.GetKeyByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := dataStorage.Asts.GetSerialized(string(key))
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, errors.New("Snapshot asset was not found")
+		}
+
+		writer.write(SNAPSHOT_ENTRY_ASSET, key, value)
+	}
+
+	writer.flush()
+
+	return writer.chunks, nil
+}
+
+func (dataStorage *DataStorage) ImportSnapshotChunk(data []byte) error {
+
+	r := helpers.NewBufferReader(data)
+
+	for r.Position < len(data) {
+
+		entryType, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		key, err := r.ReadVariableBytes(1024)
+		if err != nil {
+			return err
+		}
+
+		value, err := r.ReadVariableBytes(uint64(len(data)))
+		if err != nil {
+			return err
+		}
+
+		switch SnapshotEntryType(entryType) {
+		case SNAPSHOT_ENTRY_PLAIN_ACCOUNT:
+			err = dataStorage.PlainAccs.UpdateSerialized(string(key), value)
+		case SNAPSHOT_ENTRY_PENDING_STAKES:
+			err = dataStorage.PendingStakes.UpdateSerialized(string(key), value)
+		case SNAPSHOT_ENTRY_ACCOUNT:
+			if len(key) <= config_coins.ASSET_LENGTH {
+				return errors.New("Snapshot account key is invalid")
+			}
+			var accs *accounts.Accounts
+			if accs, err = dataStorage.AccsCollection.GetMap(key[:config_coins.ASSET_LENGTH]); err != nil {
+				return err
+			}
+			err = accs.UpdateSerialized(string(key[config_coins.ASSET_LENGTH:]), value)
+		case SNAPSHOT_ENTRY_ASSET:
+			err = dataStorage.Asts.UpdateSerialized(string(key), value)
+		default:
+			return errors.New("Snapshot entry type is invalid")
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//deletes all the stored state. Changes must be committed afterwards
+func (dataStorage *DataStorage) ClearState() error {
+
+	for _, hashMap := range dataStorage.GetListWithoutCollections() {
+
+		keys := make([]string, 0)
+		if err := hashMap.StateTree.Iterate(func(key []byte) error {
+			keys = append(keys, string(key))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			hashMap.Delete(key)
+		}
+	}
+
+	keys := make([][]byte, 0)
+	if err := dataStorage.AccsCollection.StateTree.Iterate(func(key []byte) error {
+		keys = append(keys, helpers.CloneBytes(key))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if len(key) <= config_coins.ASSET_LENGTH {
+			return errors.New("Account key is invalid")
+		}
+		accs, err := dataStorage.AccsCollection.GetMap(key[:config_coins.ASSET_LENGTH])
+		if err != nil {
+			return err
+		}
+		accs.Delete(string(key[config_coins.ASSET_LENGTH:]))
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package data_storage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestDataStorage_BuildStateTrees(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	var stateRoot []byte
+	err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := NewDataStorage(dbTx)
+
+		ast := &asset.Asset{
+			PublicKeyHash:    config_coins.NATIVE_ASSET_FULL,
+			DecimalSeparator: byte(config_coins.DECIMAL_SEPARATOR),
+			MaxSupply:        config_coins.MAX_SUPPLY_COINS_UNITS,
+			Supply:           1000,
+			UpdatePublicKey:  config_coins.BURN_PUBLIC_KEY,
+			SupplyPublicKey:  config_coins.BURN_PUBLIC_KEY,
+			Name:             config_coins.NATIVE_ASSET_NAME,
+			Ticker:           config_coins.NATIVE_ASSET_TICKER,
+			Identification:   config_coins.NATIVE_ASSET_IDENTIFICATION,
+			Description:      config_coins.NATIVE_ASSET_DESCRIPTION,
+		}
+		if err = dataStorage.Asts.CreateAsset(config_coins.NATIVE_ASSET_FULL, ast); err != nil {
+			return
+		}
+
+		for i := 0; i < 3; i++ {
+			publicKeyHash := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+			var plainAcc *plain_account.PlainAccount
+			if plainAcc, err = dataStorage.CreatePlainAccount(publicKeyHash); err != nil {
+				return
+			}
+			if err = plainAcc.IncrementNonce(true); err != nil {
+				return
+			}
+			if err = dataStorage.PlainAccs.Update(string(publicKeyHash), plainAcc); err != nil {
+				return
+			}
+			if _, _, err = dataStorage.CreateAccount(config_coins.NATIVE_ASSET_FULL, publicKeyHash); err != nil {
+				return
+			}
+			if err = dataStorage.AddStakePendingStake(publicKeyHash, 10, true, 0); err != nil {
+				return
+			}
+		}
+
+		if err = dataStorage.CommitChanges(); err != nil {
+			return
+		}
+		stateRoot, err = dataStorage.ComputeStateRoot()
+		return
+	})
+	assert.NoError(t, err)
+
+	//the state trees are removed, like in a store created before the state trees
+	err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		for _, prefix := range []string{"plainAccs:stateTree", "accounts:stateTree", "pendingStakes:stateTree", "assets:stateTree"} {
+			keys := make([]string, 0)
+			if err = dbTx.Iterate(prefix, "", false, func(key string, value []byte) bool {
+				keys = append(keys, key)
+				return true
+			}); err != nil {
+				return
+			}
+			assert.NotEqual(t, 0, len(keys), prefix)
+			for _, key := range keys {
+				dbTx.Delete(key)
+			}
+		}
+
+		dataStorage := NewDataStorage(dbTx)
+
+		var emptyStateRoot []byte
+		if emptyStateRoot, err = dataStorage.ComputeStateRoot(); err != nil {
+			return
+		}
+		assert.NotEqual(t, stateRoot, emptyStateRoot)
+
+		if err = dataStorage.BuildStateTrees(); err != nil {
+			return
+		}
+
+		var builtStateRoot []byte
+		if builtStateRoot, err = dataStorage.ComputeStateRoot(); err != nil {
+			return
+		}
+		assert.Equal(t, stateRoot, builtStateRoot)
+		return
+	})
+	assert.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package pending_stakes_list
 import (
 	"pandora-pay/blockchain/data_storage/pending_stakes_list/pending_stakes"
 	"pandora-pay/store/hash_map"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -37,6 +38,7 @@ func (self *PendingStakesList) GetPendingStakes(blockHeight uint64) (*pending_st
 func NewPendingStakesList(tx store_db_interface.StoreDBTransactionInterface) (self *PendingStakesList) {
 
 	hashmap := hash_map.CreateNewHashMap(tx, "pendingStakes", 0, false)
+	hashmap.StateTree = state_tree.CreateNewStateTree(tx, "pendingStakes:stateTree")
 
 	self = &PendingStakesList{
 		HashMap: hashmap,
@@ -4,6 +4,7 @@ import (
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/cryptography"
 	hash_map "pandora-pay/store/hash_map"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -33,6 +34,7 @@ func (plainAccounts *PlainAccounts) GetPlainAccount(key []byte) (*plain_account.
 func NewPlainAccounts(tx store_db_interface.StoreDBTransactionInterface) (plainAccs *PlainAccounts) {
 
 	hashmap := hash_map.CreateNewHashMap(tx, "plainAccs", cryptography.PublicKeyHashSize, false)
+	hashmap.StateTree = state_tree.CreateNewStateTree(tx, "plainAccs:stateTree")
 
 	plainAccs = &PlainAccounts{
 		HashMap: hashmap,
@@ -3,6 +3,7 @@ package forging
 import (
 	"github.com/tevino/abool"
 	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/forging/forging_block_work"
 	"pandora-pay/config"
 	"pandora-pay/gui"
@@ -43,13 +44,13 @@ func CreateForging(mempool *mempool.Mempool) (*Forging, error) {
 	return forging, nil
 }
 
-func (forging *Forging) InitializeForging(nextBlockCreatedCn <-chan *forging_block_work.ForgingWork, updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates], forgingSolutionCn chan<- *blockchain_types.BlockchainSolution) {
+func (forging *Forging) InitializeForging(nextBlockCreatedCn <-chan *forging_block_work.ForgingWork, updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates], forgingSolutionCn chan<- *blockchain_types.BlockchainSolution, computeStateRoot func(blkComplete *block_complete.BlockComplete) ([]byte, error)) {
 
 	forging.nextBlockCreatedCn = nextBlockCreatedCn
 	forging.Wallet.updateNewChainUpdate = updateNewChainUpdate
 	forging.forgingSolutionCn = forgingSolutionCn
 
-	forging.forgingThread = createForgingThread(config.CPU_THREADS, forging.mempool, forging.forgingSolutionCn, forging.nextBlockCreatedCn)
+	forging.forgingThread = createForgingThread(config.CPU_THREADS, forging.mempool, forging.forgingSolutionCn, forging.nextBlockCreatedCn, computeStateRoot)
 	forging.Wallet.workersCreatedCn = forging.forgingThread.workersCreatedCn
 	forging.Wallet.workersDestroyedCn = forging.forgingThread.workersDestroyedCn
 
@@ -26,6 +26,7 @@ type ForgingThread struct {
 	workersCreatedCn   chan []*ForgingWorkerThread
 	workersDestroyedCn chan struct{}
 	lastPrevKernelHash *generics.Value[[]byte]
+	computeStateRoot   func(blkComplete *block_complete.BlockComplete) ([]byte, error) //state root after including the block
 }
 
 func (thread *ForgingThread) stopForging() {
@@ -128,10 +129,16 @@ func (thread *ForgingThread) publishSolution(solution *ForgingSolution) ([]byte,
 		newBlk.Block.RewardCollector = config_nodes.DELEGATOR_REWARD_COLLECTOR_PUBLIC_KEY
 	}
 
-	hashForSignature := newBlk.Block.SerializeForSigning()
-
 	var err error
 
+	if newBlk.Block.HasStateRoot() {
+		if newBlk.Block.StateRoot, err = thread.computeStateRoot(newBlk); err != nil {
+			return nil, err
+		}
+	}
+
+	hashForSignature := newBlk.Block.SerializeForSigning()
+
 	if newBlk.Block.Signature, err = solution.address.delegatedStakePrivateKey.Sign(hashForSignature); err != nil {
 		return nil, err
 	}
@@ -153,7 +160,7 @@ func (thread *ForgingThread) publishSolution(solution *ForgingSolution) ([]byte,
 	return res.ChainKernelHash, res.Err
 }
 
-func createForgingThread(threads int, mempool *mempool.Mempool, solutionCn chan<- *blockchain_types.BlockchainSolution, nextBlockCreatedCn <-chan *forging_block_work.ForgingWork) *ForgingThread {
+func createForgingThread(threads int, mempool *mempool.Mempool, solutionCn chan<- *blockchain_types.BlockchainSolution, nextBlockCreatedCn <-chan *forging_block_work.ForgingWork, computeStateRoot func(blkComplete *block_complete.BlockComplete) ([]byte, error)) *ForgingThread {
 	return &ForgingThread{
 		mempool,
 		threads,
@@ -163,5 +170,6 @@ func createForgingThread(threads int, mempool *mempool.Mempool, solutionCn chan<
 		make(chan []*ForgingWorkerThread),
 		make(chan struct{}),
 		&generics.Value[[]byte]{},
+		computeStateRoot,
 	}
 }
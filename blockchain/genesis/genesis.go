@@ -81,7 +81,7 @@ func CreateNewGenesisBlock() (*block.Block, error) {
 
 	var blk = block.Block{
 		BlockHeader: &block.BlockHeader{
-			Version: 0,
+			Version: block.GetBlockVersion(0),
 			Height:  0,
 		},
 		MerkleHash:     cryptography.SHA3([]byte{}),
@@ -0,0 +1,98 @@
+package info
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+)
+
+type AccountTxDirection uint8
+
+const (
+	ACCOUNT_TX_ALL AccountTxDirection = iota
+	ACCOUNT_TX_INCOMING
+	ACCOUNT_TX_OUTGOING
+)
+
+//the amounts received and sent by an account in a tx for an asset
+type AccountTxDelta struct {
+	Asset    []byte `json:"asset" msgpack:"asset"`
+	Received uint64 `json:"received" msgpack:"received"`
+	Sent     uint64 `json:"sent" msgpack:"sent"`
+}
+
+type AccountTx struct {
+	Hash      []byte            `json:"hash" msgpack:"hash"`
+	BlkHeight uint64            `json:"blkHeight" msgpack:"blkHeight"`
+	Timestamp uint64            `json:"timestamp" msgpack:"timestamp"`
+	Deltas    []*AccountTxDelta `json:"deltas" msgpack:"deltas"`
+}
+
+func (accountTx *AccountTx) getDelta(asset []byte) *AccountTxDelta {
+	for _, delta := range accountTx.Deltas {
+		if bytes.Equal(delta.Asset, asset) {
+			return delta
+		}
+	}
+	delta := &AccountTxDelta{asset, 0, 0}
+	accountTx.Deltas = append(accountTx.Deltas, delta)
+	return delta
+}
+
+//asset nil matches all the assets
+func (accountTx *AccountTx) Matches(asset []byte, direction AccountTxDirection) bool {
+	if asset == nil && direction == ACCOUNT_TX_ALL {
+		return true
+	}
+	for _, delta := range accountTx.Deltas {
+		if asset != nil && !bytes.Equal(delta.Asset, asset) {
+			continue
+		}
+		switch direction {
+		case ACCOUNT_TX_INCOMING:
+			if delta.Received > 0 {
+				return true
+			}
+		case ACCOUNT_TX_OUTGOING:
+			if delta.Sent > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+//the deltas are computed from the vin and vout of the tx
+func CreateAccountTx(tx *transaction.Transaction, publicKeyHash []byte, blkHeight, timestamp uint64) (*AccountTx, error) {
+
+	accountTx := &AccountTx{
+		tx.Bloom.Hash,
+		blkHeight,
+		timestamp,
+		[]*AccountTxDelta{},
+	}
+
+	switch tx.Version {
+	case transaction_type.TX_SIMPLE:
+		txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+
+		for i, vin := range txBase.Vin {
+			if bytes.Equal(txBase.Bloom.VinPublicKeyHashes[i], publicKeyHash) {
+				accountTx.getDelta(vin.Asset).Sent += vin.Amount
+			}
+		}
+		for _, vout := range txBase.Vout {
+			if bytes.Equal(vout.PublicKeyHash, publicKeyHash) {
+				accountTx.getDelta(vout.Asset).Received += vout.Amount
+			}
+		}
+	default:
+		return nil, errors.New("Invalid tx.Version")
+	}
+
+	return accountTx, nil
+}
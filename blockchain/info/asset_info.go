@@ -8,4 +8,8 @@ type AssetInfo struct {
 	DecimalSeparator byte   `json:"decimalSeparator" msgpack:"decimalSeparator"`
 	Description      string `json:"description,omitempty" msgpack:"description,omitempty"`
 	Hash             []byte `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	MaxSupply        uint64 `json:"maxSupply" msgpack:"maxSupply"`
+	Supply           uint64 `json:"supply" msgpack:"supply"`
+	Paused           bool   `json:"paused,omitempty" msgpack:"paused,omitempty"`
+	Frozen           bool   `json:"frozen,omitempty" msgpack:"frozen,omitempty"`
 }
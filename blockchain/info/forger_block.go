@@ -0,0 +1,100 @@
+package info
+
+import (
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/config/config_reward"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//the totals are cumulative over all the previous blocks of the forger, so the stats of a height range are the difference of two records
+type ForgerBlock struct {
+	Hash              []byte `json:"hash" msgpack:"hash"`
+	BlkHeight         uint64 `json:"blkHeight" msgpack:"blkHeight"`
+	Timestamp         uint64 `json:"timestamp" msgpack:"timestamp"`
+	StakingAmount     uint64 `json:"stakingAmount" msgpack:"stakingAmount"`
+	Reward            uint64 `json:"reward" msgpack:"reward"`
+	Fees              uint64 `json:"fees" msgpack:"fees"`
+	Commission        uint64 `json:"commission" msgpack:"commission"`
+	DelegatedStakeFee uint64 `json:"delegatedStakeFee" msgpack:"delegatedStakeFee"`
+	RewardCollector   []byte `json:"rewardCollector,omitempty" msgpack:"rewardCollector,omitempty"`
+	TotalReward       uint64 `json:"totalReward" msgpack:"totalReward"`
+	TotalFees         uint64 `json:"totalFees" msgpack:"totalFees"`
+	TotalCommission   uint64 `json:"totalCommission" msgpack:"totalCommission"`
+}
+
+func CreateForgerBlock(blkComplete *block_complete.BlockComplete, prev *ForgerBlock) (forgerBlock *ForgerBlock, err error) {
+
+	var fees, commission uint64
+	if fees, err = blkComplete.ComputeFees(); err != nil {
+		return
+	}
+
+	reward := config_reward.GetRewardAt(blkComplete.Height)
+
+	var rewardCollector []byte
+	if blkComplete.DelegatedStakeFee > 0 {
+		final := reward
+		if err = helpers.SafeUint64Add(&final, fees); err != nil {
+			return
+		}
+		if commission, err = blkComplete.Block.ComputeCommission(final); err != nil {
+			return
+		}
+		rewardCollector = blkComplete.RewardCollector
+	}
+
+	forgerBlock = &ForgerBlock{
+		blkComplete.Bloom.Hash,
+		blkComplete.Height,
+		blkComplete.Timestamp,
+		blkComplete.StakingAmount,
+		reward,
+		fees,
+		commission,
+		blkComplete.DelegatedStakeFee,
+		rewardCollector,
+		reward,
+		fees,
+		commission,
+	}
+
+	if prev != nil {
+		if err = helpers.SafeUint64Add(&forgerBlock.TotalReward, prev.TotalReward); err != nil {
+			return
+		}
+		if err = helpers.SafeUint64Add(&forgerBlock.TotalFees, prev.TotalFees); err != nil {
+			return
+		}
+		if err = helpers.SafeUint64Add(&forgerBlock.TotalCommission, prev.TotalCommission); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func LoadForgerBlock(reader store_db_interface.StoreDBTransactionInterface, forger []byte, index uint64) (*ForgerBlock, error) {
+
+	data := reader.Get("forgerBlock:" + string(forger) + ":" + strconv.FormatUint(index, 10))
+	if data == nil {
+		return nil, errors.New("Forger block was not found")
+	}
+
+	forgerBlock := &ForgerBlock{}
+	if err := msgpack.Unmarshal(data, forgerBlock); err != nil {
+		return nil, err
+	}
+	return forgerBlock, nil
+}
+
+func LoadForgerBlocksCount(reader store_db_interface.StoreDBTransactionInterface, forger []byte) (uint64, error) {
+	data := reader.Get("forgerBlocksCount:" + string(forger))
+	if data == nil {
+		return 0, nil
+	}
+	return strconv.ParseUint(string(data), 10, 64)
+}
@@ -91,7 +91,12 @@ func (tx *Transaction) Verify() error {
 	return tx.VerifyBloomAll()
 }
 
-func (tx *Transaction) Deserialize(r *helpers.BufferReader) (err error) {
+func (tx *Transaction) Deserialize(r *helpers.BufferReader) error {
+	return tx.DeserializeAdvanced(r, true)
+}
+
+//unsigned transactions are not bloomed as the signatures are missing
+func (tx *Transaction) DeserializeAdvanced(r *helpers.BufferReader, inclSignature bool) (err error) {
 
 	first := r.Position
 
@@ -112,7 +117,11 @@ func (tx *Transaction) Deserialize(r *helpers.BufferReader) (err error) {
 		return
 	}
 
-	if err = tx.TransactionBaseInterface.Deserialize(r); err != nil {
+	if err = tx.TransactionBaseInterface.DeserializeAdvanced(r, inclSignature); err != nil {
+		return
+	}
+
+	if !inclSignature {
 		return
 	}
 
@@ -8,6 +8,7 @@ import (
 type TransactionBaseInterface interface {
 	helpers.SerializableInterface
 	SerializeAdvanced(w *helpers.BufferWriter, inclSignature bool)
+	DeserializeAdvanced(r *helpers.BufferReader, inclSignature bool) error
 	IncludeTransaction(blockHeight uint64, txHash []byte, dataStorage *data_storage.DataStorage) error
 	ComputeFee() (uint64, error)
 	ComputeAllKeys(out map[string]bool)
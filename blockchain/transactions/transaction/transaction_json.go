@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
@@ -23,6 +24,41 @@ type json_TransactionSimple_Extra_Unstake struct {
 	Amounts []uint64 `json:"amounts" msgpack:"amounts"`
 }
 
+type json_TransactionSimple_Extra_Stake struct {
+	Amounts []uint64 `json:"amounts" msgpack:"amounts"`
+}
+
+type json_TransactionSimple_Extra_UpdateDelegate struct {
+	DelegatedStakePublicKey []byte `json:"delegatedStakePublicKey" msgpack:"delegatedStakePublicKey"`
+	DelegatedStakeFee       uint64 `json:"delegatedStakeFee" msgpack:"delegatedStakeFee"`
+}
+
+type json_TransactionSimple_Extra_AssetCreate struct {
+	Asset *asset.Asset `json:"asset" msgpack:"asset"`
+}
+
+type json_TransactionSimple_Extra_AssetSupply struct {
+	AssetId []byte `json:"assetId" msgpack:"assetId"`
+	Value   uint64 `json:"value" msgpack:"value"`
+}
+
+type json_TransactionSimple_Extra_AssetUpdate struct {
+	AssetId         []byte `json:"assetId" msgpack:"assetId"`
+	Description     string `json:"description" msgpack:"description"`
+	Data            []byte `json:"data" msgpack:"data"`
+	UpdatePublicKey []byte `json:"updatePublicKey,omitempty" msgpack:"updatePublicKey,omitempty"`
+	SupplyPublicKey []byte `json:"supplyPublicKey,omitempty" msgpack:"supplyPublicKey,omitempty"`
+}
+
+type json_TransactionSimple_Extra_AssetPause struct {
+	AssetId []byte `json:"assetId" msgpack:"assetId"`
+	Paused  bool   `json:"paused" msgpack:"paused"`
+}
+
+type json_TransactionSimple_Extra_AssetFreeze struct {
+	AssetId []byte `json:"assetId" msgpack:"assetId"`
+}
+
 type json_TransactionSimple struct {
 	*Json_Transaction
 	TxScript    transaction_simple.ScriptType           `json:"txScript" msgpack:"txScript"`
@@ -98,6 +134,53 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 				base.Extra.(*transaction_simple_extra.TransactionSimpleExtraUnstake).Amounts,
 			}
 			simpleJson.Extra = extra
+		case transaction_simple.SCRIPT_STAKE:
+			extra := &json_TransactionSimple_Extra_Stake{
+				base.Extra.(*transaction_simple_extra.TransactionSimpleExtraStake).Amounts,
+			}
+			simpleJson.Extra = extra
+		case transaction_simple.SCRIPT_UPDATE_DELEGATE:
+			baseExtra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraUpdateDelegate)
+			extra := &json_TransactionSimple_Extra_UpdateDelegate{
+				baseExtra.DelegatedStakePublicKey,
+				baseExtra.DelegatedStakeFee,
+			}
+			simpleJson.Extra = extra
+		case transaction_simple.SCRIPT_ASSET_CREATE:
+			simpleJson.Extra = &json_TransactionSimple_Extra_AssetCreate{
+				base.Extra.(*transaction_simple_extra.TransactionSimpleExtraAssetCreate).Asset,
+			}
+		case transaction_simple.SCRIPT_ASSET_SUPPLY_INCREASE:
+			baseExtra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraAssetSupplyIncrease)
+			simpleJson.Extra = &json_TransactionSimple_Extra_AssetSupply{
+				baseExtra.AssetId,
+				baseExtra.Value,
+			}
+		case transaction_simple.SCRIPT_ASSET_SUPPLY_DECREASE:
+			baseExtra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraAssetSupplyDecrease)
+			simpleJson.Extra = &json_TransactionSimple_Extra_AssetSupply{
+				baseExtra.AssetId,
+				baseExtra.Value,
+			}
+		case transaction_simple.SCRIPT_ASSET_UPDATE:
+			baseExtra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraAssetUpdate)
+			simpleJson.Extra = &json_TransactionSimple_Extra_AssetUpdate{
+				baseExtra.AssetId,
+				baseExtra.Description,
+				baseExtra.Data,
+				baseExtra.UpdatePublicKey,
+				baseExtra.SupplyPublicKey,
+			}
+		case transaction_simple.SCRIPT_ASSET_PAUSE:
+			baseExtra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraAssetPause)
+			simpleJson.Extra = &json_TransactionSimple_Extra_AssetPause{
+				baseExtra.AssetId,
+				baseExtra.Paused,
+			}
+		case transaction_simple.SCRIPT_ASSET_FREEZE:
+			simpleJson.Extra = &json_TransactionSimple_Extra_AssetFreeze{
+				base.Extra.(*transaction_simple_extra.TransactionSimpleExtraAssetFreeze).AssetId,
+			}
 		default:
 			return nil, errors.New("Invalid simple.TxScript")
 		}
@@ -110,6 +193,13 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 
 }
 
+//the extra is decoded directly into the typed struct of the script
+func unmarshalJSONExtra(data []byte, extra any) error {
+	return json.Unmarshal(data, &struct {
+		Extra any `json:"extra"`
+	}{extra})
+}
+
 func (tx *Transaction) MarshalJSON() ([]byte, error) {
 	return marshalJSON(tx, json.Marshal)
 }
@@ -195,8 +285,76 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 		switch simpleJson.TxScript {
 		case transaction_simple.SCRIPT_TRANSFER:
 		case transaction_simple.SCRIPT_UNSTAKE:
+			extra := &json_TransactionSimple_Extra_Unstake{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
 			base.Extra = &transaction_simple_extra.TransactionSimpleExtraUnstake{
-				nil, simpleJson.Extra.(*json_TransactionSimple_Extra_Unstake).Amounts,
+				nil, extra.Amounts,
+			}
+		case transaction_simple.SCRIPT_STAKE:
+			extra := &json_TransactionSimple_Extra_Stake{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraStake{
+				nil, extra.Amounts,
+			}
+		case transaction_simple.SCRIPT_UPDATE_DELEGATE:
+			extra := &json_TransactionSimple_Extra_UpdateDelegate{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraUpdateDelegate{
+				nil, extra.DelegatedStakePublicKey, extra.DelegatedStakeFee,
+			}
+		case transaction_simple.SCRIPT_ASSET_CREATE:
+			extra := &json_TransactionSimple_Extra_AssetCreate{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetCreate{
+				nil, extra.Asset,
+			}
+		case transaction_simple.SCRIPT_ASSET_SUPPLY_INCREASE:
+			extra := &json_TransactionSimple_Extra_AssetSupply{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetSupplyIncrease{
+				nil, extra.AssetId, extra.Value,
+			}
+		case transaction_simple.SCRIPT_ASSET_SUPPLY_DECREASE:
+			extra := &json_TransactionSimple_Extra_AssetSupply{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetSupplyDecrease{
+				nil, extra.AssetId, extra.Value,
+			}
+		case transaction_simple.SCRIPT_ASSET_UPDATE:
+			extra := &json_TransactionSimple_Extra_AssetUpdate{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetUpdate{
+				nil, extra.AssetId, extra.Description, extra.Data, extra.UpdatePublicKey, extra.SupplyPublicKey,
+			}
+		case transaction_simple.SCRIPT_ASSET_PAUSE:
+			extra := &json_TransactionSimple_Extra_AssetPause{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetPause{
+				nil, extra.AssetId, extra.Paused,
+			}
+		case transaction_simple.SCRIPT_ASSET_FREEZE:
+			extra := &json_TransactionSimple_Extra_AssetFreeze{}
+			if err = unmarshalJSONExtra(data, extra); err != nil {
+				return
+			}
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetFreeze{
+				nil, extra.AssetId,
 			}
 		default:
 			return errors.New("Invalid json Simple TxScript")
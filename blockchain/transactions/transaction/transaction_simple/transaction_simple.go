@@ -6,6 +6,7 @@ import (
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/assets"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/transactions/transaction/transaction_base_interface"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
@@ -35,6 +36,10 @@ func (tx *TransactionSimple) IncludeTransaction(blockHeight uint64, txHash []byt
 	var acc *account.Account
 	var accs *accounts.Accounts
 
+	if err = tx.VerifyAssetsNotPaused(dataStorage.Asts); err != nil {
+		return
+	}
+
 	for i, vin := range tx.Vin {
 
 		if i == 0 {
@@ -85,8 +90,9 @@ func (tx *TransactionSimple) IncludeTransaction(blockHeight uint64, txHash []byt
 
 	switch tx.TxScript {
 	case SCRIPT_TRANSFER:
-	case SCRIPT_UNSTAKE:
-		if err = tx.Extra.IncludeTransactionExtra(blockHeight, tx.Bloom.VinPublicKeyHashes, tx.Vin, tx.Vout, dataStorage); err != nil {
+	case SCRIPT_UNSTAKE, SCRIPT_STAKE, SCRIPT_UPDATE_DELEGATE,
+		SCRIPT_ASSET_CREATE, SCRIPT_ASSET_SUPPLY_INCREASE, SCRIPT_ASSET_SUPPLY_DECREASE, SCRIPT_ASSET_UPDATE, SCRIPT_ASSET_PAUSE, SCRIPT_ASSET_FREEZE:
+		if err = tx.Extra.IncludeTransactionExtra(blockHeight, txHash, tx.Bloom.VinPublicKeyHashes, tx.Vin, tx.Vout, dataStorage); err != nil {
 			return
 		}
 	}
@@ -94,6 +100,31 @@ func (tx *TransactionSimple) IncludeTransaction(blockHeight uint64, txHash []byt
 	return nil
 }
 
+func (tx *TransactionSimple) GetAllAssets() map[string]bool {
+	out := make(map[string]bool)
+	for _, vin := range tx.Vin {
+		out[string(vin.Asset)] = true
+	}
+	for _, vout := range tx.Vout {
+		out[string(vout.Asset)] = true
+	}
+	return out
+}
+
+//vin and vout are not allowed for paused assets
+func (tx *TransactionSimple) VerifyAssetsNotPaused(asts *assets.Assets) error {
+	for assetId := range tx.GetAllAssets() {
+		ast, err := asts.GetAsset([]byte(assetId))
+		if err != nil {
+			return err
+		}
+		if ast != nil && ast.Paused {
+			return fmt.Errorf("Asset %s is paused", ast.Identification)
+		}
+	}
+	return nil
+}
+
 func (tx *TransactionSimple) ComputeFee() (uint64, error) {
 	if err := tx.Bloom.verifyIfBloomed(); err != nil {
 		return 0, err
@@ -143,7 +174,8 @@ func (tx *TransactionSimple) Validate() (err error) {
 
 	switch tx.TxScript {
 	case SCRIPT_TRANSFER:
-	case SCRIPT_UNSTAKE:
+	case SCRIPT_UNSTAKE, SCRIPT_STAKE, SCRIPT_UPDATE_DELEGATE,
+		SCRIPT_ASSET_CREATE, SCRIPT_ASSET_SUPPLY_INCREASE, SCRIPT_ASSET_SUPPLY_DECREASE, SCRIPT_ASSET_UPDATE, SCRIPT_ASSET_PAUSE, SCRIPT_ASSET_FREEZE:
 		if tx.Extra == nil {
 			return errors.New("extra is not assigned")
 		}
@@ -187,7 +219,11 @@ func (tx *TransactionSimple) Serialize(w *helpers.BufferWriter) {
 	tx.SerializeAdvanced(w, true)
 }
 
-func (tx *TransactionSimple) Deserialize(r *helpers.BufferReader) (err error) {
+func (tx *TransactionSimple) Deserialize(r *helpers.BufferReader) error {
+	return tx.DeserializeAdvanced(r, true)
+}
+
+func (tx *TransactionSimple) DeserializeAdvanced(r *helpers.BufferReader, inclSignature bool) (err error) {
 
 	var n uint64
 	if n, err = r.ReadUvarint(); err != nil {
@@ -199,6 +235,22 @@ func (tx *TransactionSimple) Deserialize(r *helpers.BufferReader) (err error) {
 	case SCRIPT_TRANSFER:
 	case SCRIPT_UNSTAKE:
 		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraUnstake{}
+	case SCRIPT_STAKE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraStake{}
+	case SCRIPT_UPDATE_DELEGATE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraUpdateDelegate{}
+	case SCRIPT_ASSET_CREATE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetCreate{}
+	case SCRIPT_ASSET_SUPPLY_INCREASE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetSupplyIncrease{}
+	case SCRIPT_ASSET_SUPPLY_DECREASE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetSupplyDecrease{}
+	case SCRIPT_ASSET_UPDATE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetUpdate{}
+	case SCRIPT_ASSET_PAUSE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetPause{}
+	case SCRIPT_ASSET_FREEZE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraAssetFreeze{}
 	default:
 		return errors.New("INVALID SCRIPT TYPE")
 	}
@@ -231,7 +283,7 @@ func (tx *TransactionSimple) Deserialize(r *helpers.BufferReader) (err error) {
 	tx.Vin = make([]*transaction_simple_parts.TransactionSimpleInput, c)
 	for i := range tx.Vin {
 		tx.Vin[i] = &transaction_simple_parts.TransactionSimpleInput{}
-		if err = tx.Vin[i].Deserialize(r); err != nil {
+		if err = tx.Vin[i].DeserializeAdvanced(r, inclSignature); err != nil {
 			return
 		}
 	}
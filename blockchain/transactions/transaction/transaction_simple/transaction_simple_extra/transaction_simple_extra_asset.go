@@ -0,0 +1,93 @@
+package transaction_simple_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
+)
+
+//returns the asset only if the publicKey is the authorized key returned by getKey
+func getAssetAuthorized(dataStorage *data_storage.DataStorage, assetId, publicKey []byte, getKey func(ast *asset.Asset) []byte) (ast *asset.Asset, err error) {
+
+	if bytes.Equal(assetId, config_coins.NATIVE_ASSET_FULL) {
+		return nil, errors.New("Native asset can not be changed")
+	}
+
+	if ast, err = dataStorage.Asts.GetAsset(assetId); err != nil {
+		return
+	}
+	if ast == nil {
+		return nil, errors.New("Asset doesn't exist")
+	}
+
+	if !bytes.Equal(getKey(ast), publicKey) {
+		return nil, errors.New("Vin is not signed by the asset key")
+	}
+
+	return
+}
+
+func getAssetSupplyPublicKey(ast *asset.Asset) []byte {
+	return ast.SupplyPublicKey
+}
+
+func getAssetUpdatePublicKey(ast *asset.Asset) []byte {
+	return ast.UpdatePublicKey
+}
+
+//minted coins are added to the supply key holder and burnt coins are subtracted from the supply key holder
+func includeAssetSupply(sign bool, assetId []byte, value uint64, publicKey, publicKeyHash []byte, dataStorage *data_storage.DataStorage) (err error) {
+
+	var ast *asset.Asset
+	if ast, err = getAssetAuthorized(dataStorage, assetId, publicKey, getAssetSupplyPublicKey); err != nil {
+		return
+	}
+
+	if err = ast.AddSupply(sign, value); err != nil {
+		return
+	}
+	if err = dataStorage.Asts.Update(string(assetId), ast); err != nil {
+		return
+	}
+
+	var accs *accounts.Accounts
+	var acc *account.Account
+	if accs, acc, err = dataStorage.GetOrCreateAccount(assetId, publicKeyHash); err != nil {
+		return
+	}
+	if err = acc.AddBalance(sign, value); err != nil {
+		return
+	}
+
+	return accs.Update(string(publicKeyHash), acc)
+}
+
+func validateAssetSupply(assetId []byte, value uint64) error {
+	if len(assetId) != config_coins.ASSET_LENGTH {
+		return errors.New("AssetId length is invalid")
+	}
+	if value == 0 {
+		return errors.New("Value must be greater than zero")
+	}
+	return nil
+}
+
+func serializeAssetSupply(w *helpers.BufferWriter, assetId []byte, value uint64) {
+	w.Write(assetId)
+	w.WriteUvarint(value)
+}
+
+func deserializeAssetSupply(r *helpers.BufferReader) (assetId []byte, value uint64, err error) {
+	if assetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+	if value, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/helpers"
+)
+
+/**
+Creating a new Asset
+The Asset Id is generated from the Tx Hash
+*/
+type TransactionSimpleExtraAssetCreate struct {
+	TransactionSimpleExtraInterface
+	Asset *asset.Asset
+}
+
+func (txExtra *TransactionSimpleExtraAssetCreate) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) (err error) {
+
+	assetId := asset.GenerateAssetId(txHash)
+
+	//cloned, otherwise the hashmap would keep a reference to the tx extra
+	ast := &asset.Asset{}
+	if err = ast.Deserialize(helpers.NewBufferReader(helpers.SerializeToBytes(txExtra.Asset))); err != nil {
+		return
+	}
+	ast.SetKey(assetId)
+
+	return dataStorage.Asts.CreateAsset(assetId, ast)
+}
+
+func (txExtra *TransactionSimpleExtraAssetCreate) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	if txExtra.Asset == nil {
+		return errors.New("Asset is missing")
+	}
+	if err := txExtra.Asset.ValidateParams(); err != nil {
+		return err
+	}
+	if txExtra.Asset.Supply != 0 {
+		return errors.New("Asset supply must be zero on creation")
+	}
+	if txExtra.Asset.Paused || txExtra.Asset.Frozen {
+		return errors.New("Asset can not be paused or frozen on creation")
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraAssetCreate) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	txExtra.Asset.Serialize(w)
+}
+
+func (txExtra *TransactionSimpleExtraAssetCreate) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+	txExtra.Asset = &asset.Asset{}
+	return txExtra.Asset.Deserialize(r)
+}
@@ -0,0 +1,54 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
+)
+
+/**
+Freezing the Supply of an Asset forever. It requires CanFreeze
+Vin[0] must be signed by the Asset SupplyPublicKey
+*/
+type TransactionSimpleExtraAssetFreeze struct {
+	TransactionSimpleExtraInterface
+	AssetId []byte
+}
+
+func (txExtra *TransactionSimpleExtraAssetFreeze) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) (err error) {
+
+	var ast *asset.Asset
+	if ast, err = getAssetAuthorized(dataStorage, txExtra.AssetId, vin[0].PublicKey, getAssetSupplyPublicKey); err != nil {
+		return
+	}
+
+	if !ast.CanFreeze {
+		return errors.New("Asset can not be frozen")
+	}
+	if ast.Frozen {
+		return errors.New("Asset is already frozen")
+	}
+
+	ast.Frozen = true
+
+	return dataStorage.Asts.Update(string(txExtra.AssetId), ast)
+}
+
+func (txExtra *TransactionSimpleExtraAssetFreeze) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	if len(txExtra.AssetId) != config_coins.ASSET_LENGTH {
+		return errors.New("AssetId length is invalid")
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraAssetFreeze) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	w.Write(txExtra.AssetId)
+}
+
+func (txExtra *TransactionSimpleExtraAssetFreeze) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+	txExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH)
+	return
+}
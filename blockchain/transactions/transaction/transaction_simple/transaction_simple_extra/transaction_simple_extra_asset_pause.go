@@ -0,0 +1,61 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
+)
+
+/**
+Pausing or Unpausing all transfers of an Asset. It requires CanPause
+Vin[0] must be signed by the Asset UpdatePublicKey
+*/
+type TransactionSimpleExtraAssetPause struct {
+	TransactionSimpleExtraInterface
+	AssetId []byte
+	Paused  bool
+}
+
+func (txExtra *TransactionSimpleExtraAssetPause) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) (err error) {
+
+	var ast *asset.Asset
+	if ast, err = getAssetAuthorized(dataStorage, txExtra.AssetId, vin[0].PublicKey, getAssetUpdatePublicKey); err != nil {
+		return
+	}
+
+	if !ast.CanPause {
+		return errors.New("Asset can not be paused")
+	}
+	if ast.Paused == txExtra.Paused {
+		return errors.New("Asset pause state is not changing")
+	}
+
+	ast.Paused = txExtra.Paused
+
+	return dataStorage.Asts.Update(string(txExtra.AssetId), ast)
+}
+
+func (txExtra *TransactionSimpleExtraAssetPause) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	if len(txExtra.AssetId) != config_coins.ASSET_LENGTH {
+		return errors.New("AssetId length is invalid")
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraAssetPause) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	w.Write(txExtra.AssetId)
+	w.WriteBool(txExtra.Paused)
+}
+
+func (txExtra *TransactionSimpleExtraAssetPause) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+	if txExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+	if txExtra.Paused, err = r.ReadBool(); err != nil {
+		return
+	}
+	return
+}
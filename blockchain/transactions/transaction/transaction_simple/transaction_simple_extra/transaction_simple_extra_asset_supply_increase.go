@@ -0,0 +1,34 @@
+package transaction_simple_extra
+
+import (
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/helpers"
+)
+
+/**
+Minting Value new coins of the Asset to the Vin[0] balance
+Vin[0] must be signed by the Asset SupplyPublicKey
+*/
+type TransactionSimpleExtraAssetSupplyIncrease struct {
+	TransactionSimpleExtraInterface
+	AssetId []byte
+	Value   uint64
+}
+
+func (txExtra *TransactionSimpleExtraAssetSupplyIncrease) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) error {
+	return includeAssetSupply(true, txExtra.AssetId, txExtra.Value, vin[0].PublicKey, vinPublicKeyHashes[0], dataStorage)
+}
+
+func (txExtra *TransactionSimpleExtraAssetSupplyIncrease) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	return validateAssetSupply(txExtra.AssetId, txExtra.Value)
+}
+
+func (txExtra *TransactionSimpleExtraAssetSupplyIncrease) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	serializeAssetSupply(w, txExtra.AssetId, txExtra.Value)
+}
+
+func (txExtra *TransactionSimpleExtraAssetSupplyIncrease) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+	txExtra.AssetId, txExtra.Value, err = deserializeAssetSupply(r)
+	return
+}
@@ -0,0 +1,127 @@
+package transaction_simple_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+)
+
+/**
+Updating the Description and Data of an Asset. It requires CanUpgrade
+Changing the UpdatePublicKey and the SupplyPublicKey. Empty keys are left unchanged
+Vin[0] must be signed by the Asset UpdatePublicKey
+*/
+type TransactionSimpleExtraAssetUpdate struct {
+	TransactionSimpleExtraInterface
+	AssetId         []byte
+	Description     string
+	Data            []byte
+	UpdatePublicKey []byte
+	SupplyPublicKey []byte
+}
+
+func (txExtra *TransactionSimpleExtraAssetUpdate) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) (err error) {
+
+	var ast *asset.Asset
+	if ast, err = getAssetAuthorized(dataStorage, txExtra.AssetId, vin[0].PublicKey, getAssetUpdatePublicKey); err != nil {
+		return
+	}
+
+	if ast.Description != txExtra.Description || !bytes.Equal(ast.Data, txExtra.Data) {
+		if !ast.CanUpgrade {
+			return errors.New("Asset can not be upgraded")
+		}
+		ast.Description = txExtra.Description
+		ast.Data = txExtra.Data
+	}
+
+	if len(txExtra.UpdatePublicKey) > 0 {
+		if !ast.CanChangeUpdatePublicKey {
+			return errors.New("Asset update public key can not be changed")
+		}
+		ast.UpdatePublicKey = txExtra.UpdatePublicKey
+	}
+
+	if len(txExtra.SupplyPublicKey) > 0 {
+		if !ast.CanChangeSupplyPublicKey {
+			return errors.New("Asset supply public key can not be changed")
+		}
+		ast.SupplyPublicKey = txExtra.SupplyPublicKey
+	}
+
+	return dataStorage.Asts.Update(string(txExtra.AssetId), ast)
+}
+
+func (txExtra *TransactionSimpleExtraAssetUpdate) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	if len(txExtra.AssetId) != config_coins.ASSET_LENGTH {
+		return errors.New("AssetId length is invalid")
+	}
+	if len(txExtra.Description) > 1024 {
+		return errors.New("asset description length is invalid")
+	}
+	if len(txExtra.Data) > 5120 {
+		return errors.New("asset data length is invalid")
+	}
+	if len(txExtra.UpdatePublicKey) != 0 && len(txExtra.UpdatePublicKey) != cryptography.PublicKeySize {
+		return errors.New("UpdatePublicKey length is invalid")
+	}
+	if len(txExtra.SupplyPublicKey) != 0 && len(txExtra.SupplyPublicKey) != cryptography.PublicKeySize {
+		return errors.New("SupplyPublicKey length is invalid")
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraAssetUpdate) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	w.Write(txExtra.AssetId)
+	w.WriteString(txExtra.Description)
+	w.WriteVariableBytes(txExtra.Data)
+
+	w.WriteBool(len(txExtra.UpdatePublicKey) > 0)
+	if len(txExtra.UpdatePublicKey) > 0 {
+		w.Write(txExtra.UpdatePublicKey)
+	}
+
+	w.WriteBool(len(txExtra.SupplyPublicKey) > 0)
+	if len(txExtra.SupplyPublicKey) > 0 {
+		w.Write(txExtra.SupplyPublicKey)
+	}
+}
+
+func (txExtra *TransactionSimpleExtraAssetUpdate) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+
+	if txExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+	if txExtra.Description, err = r.ReadString(1024); err != nil {
+		return
+	}
+	if txExtra.Data, err = r.ReadVariableBytes(5120); err != nil {
+		return
+	}
+
+	var hasKey bool
+	if hasKey, err = r.ReadBool(); err != nil {
+		return
+	}
+	if hasKey {
+		if txExtra.UpdatePublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+	}
+
+	if hasKey, err = r.ReadBool(); err != nil {
+		return
+	}
+	if hasKey {
+		if txExtra.SupplyPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,83 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
+)
+
+/**
+Substracting Amount from the native Balance
+Creating a Stake Pending
+*/
+type TransactionSimpleExtraStake struct {
+	TransactionSimpleExtraInterface
+	Amounts []uint64
+}
+
+func (txExtra *TransactionSimpleExtraStake) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) (err error) {
+
+	var accs *accounts.Accounts
+	var acc *account.Account
+
+	if accs, err = dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL); err != nil {
+		return
+	}
+
+	for i := range vin {
+
+		if acc, err = accs.GetAccount(vinPublicKeyHashes[i]); err != nil {
+			return
+		}
+		if acc == nil {
+			return errors.New("Account doesn't exist")
+		}
+
+		if err = acc.AddBalance(false, txExtra.Amounts[i]); err != nil {
+			return
+		}
+
+		if err = accs.Update(string(vinPublicKeyHashes[i]), acc); err != nil {
+			return
+		}
+
+		if err = dataStorage.AddStakePendingStake(vinPublicKeyHashes[i], txExtra.Amounts[i], true, blockHeight); err != nil {
+			return
+		}
+
+	}
+
+	return
+}
+
+func (txExtra *TransactionSimpleExtraStake) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	if len(vin) != len(txExtra.Amounts) {
+		return errors.New("Invalid length")
+	}
+	for _, amount := range txExtra.Amounts {
+		if amount == 0 {
+			return errors.New("Stake must be greater than zero")
+		}
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraStake) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	for _, amount := range txExtra.Amounts {
+		w.WriteUvarint(amount)
+	}
+}
+
+func (txExtra *TransactionSimpleExtraStake) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+	txExtra.Amounts = make([]uint64, len(vin))
+	for i := range txExtra.Amounts {
+		if txExtra.Amounts[i], err = r.ReadUvarint(); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+)
+
+/**
+Creating or Updating the DelegatedStake of the vin account
+The DelegatedStakeNonce is incremented with each update
+*/
+type TransactionSimpleExtraUpdateDelegate struct {
+	TransactionSimpleExtraInterface
+	DelegatedStakePublicKey []byte
+	DelegatedStakeFee       uint64
+}
+
+func (txExtra *TransactionSimpleExtraUpdateDelegate) IncludeTransactionExtra(blockHeight uint64, txHash []byte, vinPublicKeyHashes [][]byte, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, dataStorage *data_storage.DataStorage) (err error) {
+
+	var plainAcc *plain_account.PlainAccount
+	if plainAcc, err = dataStorage.GetOrCreatePlainAccount(vinPublicKeyHashes[0]); err != nil {
+		return
+	}
+
+	delegatedStakeNonce := uint64(0)
+	if plainAcc.DelegatedStake.HasDelegatedStake() {
+		delegatedStakeNonce = plainAcc.DelegatedStake.DelegatedStakeNonce
+		if err = helpers.SafeUint64Add(&delegatedStakeNonce, 1); err != nil {
+			return
+		}
+	}
+
+	if err = plainAcc.DelegatedStake.CreateDelegatedStake(delegatedStakeNonce, txExtra.DelegatedStakePublicKey, txExtra.DelegatedStakeFee); err != nil {
+		return
+	}
+
+	return dataStorage.PlainAccs.Update(string(vinPublicKeyHashes[0]), plainAcc)
+}
+
+func (txExtra *TransactionSimpleExtraUpdateDelegate) Validate(vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) error {
+	if len(vin) != 1 {
+		return errors.New("Update Delegate requires exactly one vin")
+	}
+	if len(txExtra.DelegatedStakePublicKey) != cryptography.PublicKeySize {
+		return errors.New("DelegatedStakePublicKey length is invalid")
+	}
+	if txExtra.DelegatedStakeFee > config_stake.DELEGATING_STAKING_FEE_MAX_VALUE {
+		return errors.New("DelegatedStakeFee is invalid")
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraUpdateDelegate) Serialize(w *helpers.BufferWriter, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput, inclSignature bool) {
+	w.Write(txExtra.DelegatedStakePublicKey)
+	w.WriteUvarint(txExtra.DelegatedStakeFee)
+}
+
+func (txExtra *TransactionSimpleExtraUpdateDelegate) Deserialize(r *helpers.BufferReader, vin []*transaction_simple_parts.TransactionSimpleInput, vout []*transaction_simple_parts.TransactionSimpleOutput) (err error) {
+	if txExtra.DelegatedStakePublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if txExtra.DelegatedStakeFee, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	return
+}
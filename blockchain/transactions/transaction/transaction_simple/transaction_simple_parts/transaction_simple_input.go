@@ -42,7 +42,11 @@ func (vin *TransactionSimpleInput) Serialize(w *helpers.BufferWriter, inclSignat
 	}
 }
 
-func (vin *TransactionSimpleInput) Deserialize(r *helpers.BufferReader) (err error) {
+func (vin *TransactionSimpleInput) Deserialize(r *helpers.BufferReader) error {
+	return vin.DeserializeAdvanced(r, true)
+}
+
+func (vin *TransactionSimpleInput) DeserializeAdvanced(r *helpers.BufferReader, inclSignature bool) (err error) {
 	if vin.PublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
 		return
 	}
@@ -52,6 +56,11 @@ func (vin *TransactionSimpleInput) Deserialize(r *helpers.BufferReader) (err err
 	if vin.Asset, err = r.ReadAsset(); err != nil {
 		return
 	}
+	if !inclSignature {
+		//the vin will be signed later
+		vin.Signature = make([]byte, cryptography.SignatureSize)
+		return
+	}
 	if vin.Signature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
 		return
 	}
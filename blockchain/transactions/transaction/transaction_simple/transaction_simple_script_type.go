@@ -5,6 +5,14 @@ type ScriptType uint64
 const (
 	SCRIPT_TRANSFER ScriptType = iota
 	SCRIPT_UNSTAKE
+	SCRIPT_STAKE
+	SCRIPT_UPDATE_DELEGATE
+	SCRIPT_ASSET_CREATE
+	SCRIPT_ASSET_SUPPLY_INCREASE
+	SCRIPT_ASSET_SUPPLY_DECREASE
+	SCRIPT_ASSET_UPDATE
+	SCRIPT_ASSET_PAUSE
+	SCRIPT_ASSET_FREEZE
 )
 
 func (t ScriptType) String() string {
@@ -13,6 +21,22 @@ func (t ScriptType) String() string {
 		return "SCRIPT_TRANSFER"
 	case SCRIPT_UNSTAKE:
 		return "SCRIPT_UNSTAKE"
+	case SCRIPT_STAKE:
+		return "SCRIPT_STAKE"
+	case SCRIPT_UPDATE_DELEGATE:
+		return "SCRIPT_UPDATE_DELEGATE"
+	case SCRIPT_ASSET_CREATE:
+		return "SCRIPT_ASSET_CREATE"
+	case SCRIPT_ASSET_SUPPLY_INCREASE:
+		return "SCRIPT_ASSET_SUPPLY_INCREASE"
+	case SCRIPT_ASSET_SUPPLY_DECREASE:
+		return "SCRIPT_ASSET_SUPPLY_DECREASE"
+	case SCRIPT_ASSET_UPDATE:
+		return "SCRIPT_ASSET_UPDATE"
+	case SCRIPT_ASSET_PAUSE:
+		return "SCRIPT_ASSET_PAUSE"
+	case SCRIPT_ASSET_FREEZE:
+		return "SCRIPT_ASSET_FREEZE"
 	default:
 		return "Unknown ScriptType"
 	}
@@ -50,6 +50,8 @@ func Initialize() {
 				"importWalletJSON":        js.FuncOf(importWalletJSON),
 				"exportWalletJSON":        js.FuncOf(exportWalletJSON),
 				"importWalletAddressJSON": js.FuncOf(importWalletAddressJSON),
+				"importWatchOnly":         js.FuncOf(importWatchOnlyWalletAddress),
+				"exportWatchOnly":         js.FuncOf(exportWatchOnlyWalletAddresses),
 				"encryption": js.ValueOf(map[string]interface{}{
 					"checkPasswordWallet":    js.FuncOf(checkPasswordWallet),
 					"encryptWallet":          js.FuncOf(encryptWallet),
@@ -80,6 +82,7 @@ func Initialize() {
 			"RIPEMD_SIZE":          js.ValueOf(cryptography.RipemdSize),
 			"PUBLIC_KEY_HASH_SIZE": js.ValueOf(cryptography.PublicKeyHashSize),
 			"CHECK_SUM_SIZE":       js.ValueOf(cryptography.ChecksumSize),
+			"verifyTxProof":        js.FuncOf(verifyTxProof),
 		}),
 		"network": js.ValueOf(map[string]interface{}{
 			"networkDisconnect":                      js.FuncOf(networkDisconnect),
@@ -91,6 +94,7 @@ func Initialize() {
 			"getNetworkBlockWithTxs":                 js.FuncOf(getNetworkBlockWithTxs),
 			"getNetworkTx":                           js.FuncOf(getNetworkTx),
 			"getNetworkTxExists":                     js.FuncOf(getNetworkTxExists),
+			"getNetworkTxProof":                      js.FuncOf(getNetworkTxProof),
 			"getNetworkBlockExists":                  js.FuncOf(getNetworkBlockExists),
 			"getNetworkTxPreview":                    js.FuncOf(getNetworkTxPreview),
 			"getNetworkAccount":                      js.FuncOf(getNetworkAccount),
@@ -152,6 +156,7 @@ func Initialize() {
 						"SUBSCRIPTION_ACCOUNT_TRANSACTIONS": js.ValueOf(int(api_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS)),
 						"SUBSCRIPTION_ASSET":                js.ValueOf(int(api_types.SUBSCRIPTION_ASSET)),
 						"SUBSCRIPTION_TRANSACTION":          js.ValueOf(int(api_types.SUBSCRIPTION_TRANSACTION)),
+						"SUBSCRIPTION_FORGER":               js.ValueOf(int(api_types.SUBSCRIPTION_FORGER)),
 					}),
 				}),
 			}),
@@ -1 +1,30 @@
 package main
+
+import (
+	"encoding/base64"
+	"pandora-pay/builds/webassembly/webassembly_utils"
+	"pandora-pay/network/api/api_common"
+	"syscall/js"
+)
+
+//the block hash must be obtained from a trusted source
+func verifyTxProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		proof := &api_common.APITxProofReply{}
+		if err := webassembly_utils.UnmarshalBytes(args[0], proof); err != nil {
+			return nil, err
+		}
+
+		blockHash, err := base64.StdEncoding.DecodeString(args[1].String())
+		if err != nil {
+			return nil, err
+		}
+
+		if err = proof.Verify(blockHash); err != nil {
+			return nil, err
+		}
+
+		return true, nil
+	})
+}
@@ -121,6 +121,9 @@ func listenNetworkNotifications(this js.Value, args []js.Value) interface{} {
 				case api_types.SUBSCRIPTION_TRANSACTION:
 					object = data.Data
 					extra = &api_types.APISubscriptionNotificationTxExtra{}
+				case api_types.SUBSCRIPTION_FORGER:
+					object = data.Data
+					extra = &api_types.APISubscriptionNotificationForgerExtra{}
 				}
 
 				if err = msgpack.Unmarshal(data.Extra, extra); err != nil {
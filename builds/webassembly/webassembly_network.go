@@ -60,7 +60,7 @@ func getNetworkBlockInfo(this js.Value, args []js.Value) interface{} {
 func getNetworkBlockWithTxs(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
-		request := &api_common.APIBlockRequest{0, nil, api_types.RETURN_SERIALIZED}
+		request := &api_common.APIBlockRequest{0, nil, api_types.RETURN_SERIALIZED, api_types.APIChainPin{}}
 		if err := webassembly_utils.UnmarshalBytes(args[0], request); err != nil {
 			return nil, err
 		}
@@ -90,14 +90,14 @@ func getNetworkAccountsCount(this js.Value, args []js.Value) interface{} {
 			return nil, err
 		}
 
-		return webassembly_utils.ConvertToJSONBytes(connection.SendJSONAwaitAnswer[api_common.APIAccountsCountReply](app.Network.Websockets.GetFirstSocket(), []byte("accounts/count"), &api_common.APIAccountsCountRequest{assetId}, nil, 0))
+		return webassembly_utils.ConvertToJSONBytes(connection.SendJSONAwaitAnswer[api_common.APIAccountsCountReply](app.Network.Websockets.GetFirstSocket(), []byte("accounts/count"), &api_common.APIAccountsCountRequest{assetId, api_types.APIChainPin{}}, nil, 0))
 	})
 }
 
 func getNetworkAccount(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
-		request := &api_common.APIAccountRequest{api_types.APIAccountBaseRequest{}, api_types.RETURN_SERIALIZED}
+		request := &api_common.APIAccountRequest{api_types.APIAccountBaseRequest{}, api_types.RETURN_SERIALIZED, api_types.APIChainPin{}}
 		err := webassembly_utils.UnmarshalBytes(args[0], request)
 		if err != nil {
 			return nil, err
@@ -179,7 +179,7 @@ func getNetworkAccountMempoolNonce(this js.Value, args []js.Value) interface{} {
 func getNetworkTx(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
-		request := &api_common.APITxRequest{0, nil, api_types.RETURN_SERIALIZED}
+		request := &api_common.APITxRequest{0, nil, api_types.RETURN_SERIALIZED, api_types.APIChainPin{}}
 		if err := webassembly_utils.UnmarshalBytes(args[0], request); err != nil {
 			return nil, err
 		}
@@ -198,6 +198,18 @@ func getNetworkTx(this js.Value, args []js.Value) interface{} {
 	})
 }
 
+func getNetworkTxProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		request := &api_common.APITxProofRequest{}
+		if err := webassembly_utils.UnmarshalBytes(args[0], request); err != nil {
+			return nil, err
+		}
+
+		return webassembly_utils.ConvertToJSONBytes(connection.SendJSONAwaitAnswer[api_common.APITxProofReply](app.Network.Websockets.GetFirstSocket(), []byte("tx/proof"), request, nil, 0))
+	})
+}
+
 func getNetworkTxExists(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
@@ -235,7 +247,7 @@ func getNetworkBlockExists(this js.Value, args []js.Value) interface{} {
 func getNetworkTxPreview(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
-		request := &api_common.APITransactionPreviewRequest{0, nil}
+		request := &api_common.APITransactionPreviewRequest{0, nil, api_types.APIChainPin{}}
 		if err := webassembly_utils.UnmarshalBytes(args[0], request); err != nil {
 			return nil, err
 		}
@@ -268,7 +280,7 @@ func getNetworkAsset(this js.Value, args []js.Value) interface{} {
 			return nil, err
 		}
 
-		final, err := connection.SendJSONAwaitAnswer[api_common.APIAssetReply](app.Network.Websockets.GetFirstSocket(), []byte("asset"), &api_common.APIAssetRequest{request.Height, request.Hash, api_types.RETURN_SERIALIZED}, nil, 0)
+		final, err := connection.SendJSONAwaitAnswer[api_common.APIAssetReply](app.Network.Websockets.GetFirstSocket(), []byte("asset"), &api_common.APIAssetRequest{request.Height, request.Hash, api_types.RETURN_SERIALIZED, api_types.APIChainPin{}}, nil, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -37,21 +37,9 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			return nil, err
 		}
 
-		var payloadExtra wizard.WizardTxSimpleExtra
-		switch txData.TxScript {
-		case transaction_simple.SCRIPT_TRANSFER:
-		default:
-			return nil, errors.New("Invalid PayloadScriptType")
-		}
-
-		if payloadExtra != nil {
-			data, err := json.Marshal(txData.Extra)
-			if err != nil {
-				return nil, err
-			}
-			if err = json.Unmarshal(data, payloadExtra); err != nil {
-				return nil, err
-			}
+		payloadExtra, err := wizard.ConvertSimpleTxExtra(txData.TxScript, txData.Extra)
+		if err != nil {
+			return nil, err
 		}
 
 		vin := make([]*wizard.WizardTxSimpleTransferVin, len(txData.Vin))
@@ -64,7 +52,7 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 				return nil, err
 			}
 
-			if senderWalletAddr.PrivateKey.Key == nil {
+			if senderWalletAddr.IsWatchOnly || senderWalletAddr.PrivateKey == nil {
 				return nil, errors.New("Can't be used for transactions as the private key is missing")
 			}
 
@@ -72,6 +60,7 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 				senderWalletAddr.PrivateKey.Key,
 				v.Amount,
 				v.Asset,
+				nil,
 			}
 
 		}
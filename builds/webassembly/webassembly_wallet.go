@@ -202,6 +202,41 @@ func importWalletAddressJSON(this js.Value, args []js.Value) interface{} {
 	})
 }
 
+func importWatchOnlyWalletAddress(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		if err := app.Wallet.Encryption.CheckPassword(args[0].String(), false); err != nil {
+			return nil, err
+		}
+
+		parameters := &struct {
+			Name      string         `json:"name"`
+			Address   string         `json:"address"`
+			PublicKey helpers.Base64 `json:"publicKey"`
+		}{}
+
+		if err := webassembly_utils.UnmarshalBytes(args[1], parameters); err != nil {
+			return nil, err
+		}
+
+		adr, err := app.Wallet.ImportWatchOnlyAddress(parameters.Name, parameters.Address, parameters.PublicKey, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return webassembly_utils.ConvertJSONBytes(adr)
+	})
+}
+
+func exportWatchOnlyWalletAddresses(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+		if err := app.Wallet.Encryption.CheckPassword(args[0].String(), false); err != nil {
+			return nil, err
+		}
+		return webassembly_utils.ConvertJSONBytes(app.Wallet.ExportWatchOnlyAddresses(true))
+	})
+}
+
 func checkPasswordWallet(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 		if err := app.Wallet.Encryption.CheckPassword(args[0].String(), false); err != nil {
@@ -8,7 +8,7 @@ package arguments
 const commands = `PANDORA PAY.
 
 Usage:
-  pandorapay [--pprof] [--network=network] [--debug] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--tor-onion=onion] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--seed-wallet-nodes-info=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--auth-users=args] [--light-computations] [--delegator-fee=fee] [--delegator-reward-collector-pub-key=pubKey] [--delegator-accept-custom-keys=bool] [--exit] [--skip-init-sync]
+  pandorapay [--pprof] [--network=network] [--debug] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--tor-onion=onion] [--instance=prefix] [--instance-id=id] [--data-dir=path] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--store-chain-migrate=type] [--consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--seed-wallet-nodes-info=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--auth-users=args] [--light-computations] [--delegator-fee=fee] [--delegator-reward-collector-pub-key=pubKey] [--delegator-accept-custom-keys=bool] [--exit] [--skip-init-sync] [--snapshot-sync=checkpoint] [--prune=blocks] [--chain-export=path] [--chain-import=path] [--verify-store=mode] [--reindex-info]
   pandorapay -h | --help
   pandorapay -v | --version
 
@@ -20,13 +20,15 @@ Options:
   --run-testnet-script                               Run testnet script which will create dummy transactions in the network.
   --set-genesis=genesis                              Manually set the Genesis via a JSON. By using argument "file" it will read it via a file.
   --create-new-genesis=args                          Create a new Genesis. Useful for creating a new private testnet. Argument must be "0.stake,1.stake,2.stake"
-  --store-wallet-type=type                           Set Wallet Store Type. Accepted values: "bolt|bunt|bunt-memory|memory". [default: bolt]
-  --store-chain-type=type                            Set Chain Store Type. Accepted values: "bolt|bunt|bunt-memory|memory".  [default: bolt]
+  --store-wallet-type=type                           Set Wallet Store Type. Accepted values: "bolt|bunt|bunt-memory|memory|leveldb". [default: bolt]
+  --store-chain-type=type                            Set Chain Store Type. Accepted values: "bolt|bunt|bunt-memory|memory|leveldb".  [default: bolt]
+  --store-chain-migrate=type                         Copy the chain store of the given type into the new empty store set by --store-chain-type. Accepted values: "bolt|bunt|leveldb".
   --debug                                            Debug mode enabled (print log message).
   --forging                                          Start Forging blocks.
   --node-name=name                                   Change node name.
   --instance=prefix                                  Prefix of the instance [default: 0].
   --instance-id=id                                   Number of forked instance (when you open multiple instances). It should be a string number like "1","2","3","4" etc
+  --data-dir=path                                    Folder where the node keeps its data, split by network. By default ./_build/webd2 of the working directory.
   --tcp-server-port=port                             Change node tcp server port [default: 8080].
   --tcp-max-clients=limit                            Change limit of clients [default: 50].
   --tcp-max-server-sockets=limit                     Change limit of servers [default: 500].
@@ -55,4 +57,10 @@ Options:
   --light-computations                               Reduces the computations for a testnet node.
   --exit                                             Exit node.
   --skip-init-sync                                   Skip sync wait at when the node started. Useful when creating a new testnet.
+  --snapshot-sync=checkpoint                         Fast sync a new node by downloading the state after a trusted checkpoint block. Argument must be "height,hash" of the checkpoint block.
+  --prune=blocks                                     Keep the block bodies, transactions and state transitions only for the last blocks. Argument must be the number of blocks kept or "true" to keep the minimum required.
+  --chain-export=path                                Export all the blocks of the chain to a file.
+  --chain-import=path                                Import the blocks from a file exported with --chain-export. The blocks are validated like the blocks received from the network.
+  --verify-store=mode                                Verify that the chain store indexes and counters agree and report the mismatches. Accepted values: "check|rebuild". rebuild writes again the wallet nodes info indexes from the stored blocks.
+  --reindex-info                                     Delete and write again the wallet nodes info indexes by replaying the stored blocks. It requires --seed-wallet-nodes-info="true". An interrupted reindex is resumed at the next start.
 `
@@ -6,33 +6,24 @@ package config
 import (
 	"os"
 	"pandora-pay/config/globals"
+	"path/filepath"
 	"strconv"
 )
 
 func config_init() (err error) {
 
-	if _, err = os.Stat("./_build"); os.IsNotExist(err) {
-		if err = os.Mkdir("./_build", 0755); err != nil {
-			return
-		}
-	}
-
 	if ORIGINAL_PATH, err = os.Getwd(); err != nil {
 		return
 	}
 
-	if err = os.Chdir("./_build"); err != nil {
-		return
-	}
-
-	if _, err = os.Stat("./webd2"); os.IsNotExist(err) {
-		if err = os.Mkdir("./webd2", 0755); err != nil {
+	//without --data-dir the data is kept in ./_build/webd2 of the working directory
+	var base string
+	if globals.Arguments["--data-dir"] != nil {
+		if base, err = filepath.Abs(globals.Arguments["--data-dir"].(string)); err != nil {
 			return
 		}
-	}
-
-	if err = os.Chdir("./webd2"); err != nil {
-		return
+	} else {
+		base = filepath.Join(ORIGINAL_PATH, "_build", "webd2")
 	}
 
 	var prefix string
@@ -51,20 +42,19 @@ func config_init() (err error) {
 	}
 	prefix += "_" + strconv.Itoa(INSTANCE_ID)
 
-	if _, err = os.Stat("./" + prefix); os.IsNotExist(err) {
-		if err = os.Mkdir("./"+prefix, 0755); err != nil {
-			return
-		}
+	//a --data-dir already separates the nodes, so the instance folder is not used
+	if globals.Arguments["--data-dir"] == nil {
+		base = filepath.Join(base, prefix)
 	}
 
-	prefix += "/" + NETWORK_SELECTED_NAME
-	if _, err = os.Stat("./" + prefix); os.IsNotExist(err) {
-		if err = os.Mkdir("./"+prefix, 0755); err != nil {
-			return
-		}
+	DATA_PATH = filepath.Join(base, NETWORK_SELECTED_NAME)
+	STORE_PATH = filepath.Join(DATA_PATH, "store")
+
+	if err = os.MkdirAll(DATA_PATH, 0755); err != nil {
+		return
 	}
 
-	if err = os.Chdir("./" + prefix); err != nil {
+	if err = os.Chdir(DATA_PATH); err != nil {
 		return
 	}
 
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"github.com/blang/semver/v4"
+	"math"
 	"math/big"
 	"math/rand"
 	"pandora-pay/config/config_auth"
@@ -11,6 +14,7 @@ import (
 	"pandora-pay/config/globals"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -25,6 +29,8 @@ var (
 	BUILD_VERSION      = ""
 	LIGHT_COMPUTATIONS = false
 	ORIGINAL_PATH      = "" //the original path where the software is located
+	DATA_PATH          = "" //the absolute folder of the selected network where the node keeps its data
+	STORE_PATH         = "" //the absolute folder where the stores are located
 )
 
 const (
@@ -54,6 +60,49 @@ const (
 	FORK_MAX_DOWNLOAD       uint64 = 20
 )
 
+var (
+	MAIN_NET_BLOCK_STATE_ROOT_HEIGHT uint64 = math.MaxUint64 //not scheduled yet
+	TEST_NET_BLOCK_STATE_ROOT_HEIGHT uint64 = math.MaxUint64 //not scheduled yet
+	DEV_NET_BLOCK_STATE_ROOT_HEIGHT  uint64 = 0
+	BLOCK_STATE_ROOT_HEIGHT                 = MAIN_NET_BLOCK_STATE_ROOT_HEIGHT //starting with this height, the blocks commit the state root
+)
+
+const (
+	SNAPSHOT_CHUNK_SIZE                    = 512 * 1024
+	SNAPSHOT_CHUNK_MAX_SIZE                = 2 * SNAPSHOT_CHUNK_SIZE //the last entry of a chunk can exceed the chunk size
+	SNAPSHOT_CHUNKS_MAX                    = 4096
+	SNAPSHOT_HEIGHT_INTERVAL        uint64 = 10000 //the state is stored as snapshot every interval blocks
+	SNAPSHOT_KEEP                   uint64 = 2     //number of the last snapshots stored
+	SNAPSHOT_HEADERS_MAX_PER_REQUEST       = 500
+)
+
+var (
+	SNAPSHOT_SYNC                   = false
+	SNAPSHOT_SYNC_CHECKPOINT_HEIGHT uint64
+	SNAPSHOT_SYNC_CHECKPOINT_HASH   []byte
+)
+
+const (
+	CHAIN_EXPORT_VERSION    uint64 = 0
+	CHAIN_IMPORT_BATCH_SIZE        = 100 //number of blocks included at once by the import
+)
+
+const (
+	STORE_MIGRATE_BATCH_SIZE         = 10000 //number of entries copied in a db tx by the store migration
+	STORE_DELETE_BATCH_SIZE          = 10000 //number of entries deleted in a db tx when the indexes are rebuilt
+	STORE_REBUILD_BATCH_SIZE         = 100   //number of blocks whose indexes are rebuilt in a db tx
+	STORE_REBUILD_HOLDERS_BATCH_SIZE = 10000 //number of accounts inserted in the asset holders in a db tx when the indexes are rebuilt
+)
+
+const (
+	PRUNE_MAX_BLOCKS_PER_UPDATE uint64 = 1000 //limits the size of the db tx when the pruning is enabled on an old chain
+)
+
+var (
+	PRUNE        = false
+	PRUNE_BLOCKS = FORK_MAX_UNCLE_ALLOWED //number of the last blocks kept with full data
+)
+
 var (
 	NETWORK_SELECTED                 = MAIN_NET_NETWORK_BYTE
 	NETWORK_SELECTED_BYTE_PREFIX     = MAIN_NET_NETWORK_BYTE_PREFIX
@@ -75,9 +124,15 @@ const (
 )
 
 var (
-	API_MEMPOOL_MAX_TRANSACTIONS = 50
-	API_ACCOUNT_MAX_TXS          = uint64(10)
-	API_ASSETS_INFO_MAX_RESULTS  = 10
+	API_MEMPOOL_MAX_TRANSACTIONS  = 50
+	API_ACCOUNT_MAX_TXS           = uint64(10)
+	API_ACCOUNT_TXS_MAX_SCAN      = uint64(1000)
+	API_ASSETS_INFO_MAX_RESULTS   = 10
+	API_ASSET_HOLDERS_MAX_RESULTS = uint64(20)
+	API_ASSET_HOLDERS_MAX_RANK    = uint64(1000)
+	API_FORGER_BLOCKS_MAX_RESULTS = uint64(20)
+	API_MEMPOOL_NEW_TXS_MAX       = 100
+	API_BATCH_MAX_REQUESTS        = 50
 )
 
 var (
@@ -114,12 +169,14 @@ func InitConfig() (err error) {
 		NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.TEST_NET_DELEGATOR_NODES
 		NETWORK_SELECTED_NAME = TEST_NET_NETWORK_NAME
 		NETWORK_SELECTED_BYTE_PREFIX = TEST_NET_NETWORK_BYTE_PREFIX
+		BLOCK_STATE_ROOT_HEIGHT = TEST_NET_BLOCK_STATE_ROOT_HEIGHT
 	} else if globals.Arguments["--network"] == "devnet" {
 		NETWORK_SELECTED = DEV_NET_NETWORK_BYTE
 		NETWORK_SELECTED_SEEDS = DEV_NET_SEED_NODES
 		NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.DEV_NET_DELEGATOR_NODES
 		NETWORK_SELECTED_NAME = DEV_NET_NETWORK_NAME
 		NETWORK_SELECTED_BYTE_PREFIX = DEV_NET_NETWORK_BYTE_PREFIX
+		BLOCK_STATE_ROOT_HEIGHT = DEV_NET_BLOCK_STATE_ROOT_HEIGHT
 	} else {
 		return errors.New("selected --network is invalid. Accepted only: mainnet, testnet, devnet")
 	}
@@ -159,6 +216,47 @@ func InitConfig() (err error) {
 		LIGHT_COMPUTATIONS = true
 	}
 
+	if globals.Arguments["--snapshot-sync"] != nil {
+		if CONSENSUS != CONSENSUS_TYPE_FULL {
+			return errors.New("--snapshot-sync requires full consensus")
+		}
+		SNAPSHOT_SYNC = true
+		checkpoint := strings.Split(globals.Arguments["--snapshot-sync"].(string), ",")
+		if len(checkpoint) != 2 {
+			return errors.New("--snapshot-sync checkpoint must be \"height,hash\"")
+		}
+		if SNAPSHOT_SYNC_CHECKPOINT_HEIGHT, err = strconv.ParseUint(checkpoint[0], 10, 64); err != nil {
+			return
+		}
+		if SNAPSHOT_SYNC_CHECKPOINT_HEIGHT == 0 || SNAPSHOT_SYNC_CHECKPOINT_HEIGHT%SNAPSHOT_HEIGHT_INTERVAL != 0 {
+			return fmt.Errorf("--snapshot-sync checkpoint height must be a multiple of %d", SNAPSHOT_HEIGHT_INTERVAL)
+		}
+		if SNAPSHOT_SYNC_CHECKPOINT_HASH, err = base64.StdEncoding.DecodeString(checkpoint[1]); err != nil {
+			return
+		}
+		if len(SNAPSHOT_SYNC_CHECKPOINT_HASH) != 32 {
+			return errors.New("--snapshot-sync checkpoint hash is invalid")
+		}
+	}
+
+	if globals.Arguments["--prune"] != nil {
+		if CONSENSUS != CONSENSUS_TYPE_FULL {
+			return errors.New("--prune requires full consensus")
+		}
+		if SEED_WALLET_NODES_INFO {
+			return errors.New("--prune can't be used with --seed-wallet-nodes-info")
+		}
+		PRUNE = true
+		if value := globals.Arguments["--prune"].(string); value != "true" {
+			if PRUNE_BLOCKS, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return
+			}
+			if PRUNE_BLOCKS < FORK_MAX_UNCLE_ALLOWED {
+				return fmt.Errorf("--prune must keep at least %d blocks", FORK_MAX_UNCLE_ALLOWED)
+			}
+		}
+	}
+
 	if NETWORK_SELECTED == TEST_NET_NETWORK_BYTE || NETWORK_SELECTED == DEV_NET_NETWORK_BYTE {
 
 		if globals.Arguments["--hcaptcha-secret"] != nil {
@@ -0,0 +1,159 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/sha3"
+	"io"
+	"math/big"
+)
+
+var curve25519P, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+
+const X25519EncryptionOverhead = curve25519.PointSize + 12 + 16 //ephemeral public key + nonce + tag
+
+func reverseBytes(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[len(b)-1-i]
+	}
+	return out
+}
+
+//converting the ed25519 public key (edwards y) into the x25519 public key (montgomery u = (1+y)/(1-y))
+func ConvertPublicKeyToX25519(publicKey []byte) ([]byte, error) {
+
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, errors.New("Invalid public key length")
+	}
+
+	buf := reverseBytes(publicKey)
+	buf[0] &= 0x7f
+
+	y := new(big.Int).SetBytes(buf)
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, errors.New("Invalid public key")
+	}
+
+	denominator := new(big.Int).Sub(big.NewInt(1), y)
+	denominator.Mod(denominator, curve25519P)
+	if denominator.Sign() == 0 {
+		return nil, errors.New("Invalid public key")
+	}
+	denominator.ModInverse(denominator, curve25519P)
+
+	u := new(big.Int).Add(big.NewInt(1), y)
+	u.Mul(u, denominator)
+	u.Mod(u, curve25519P)
+
+	out := make([]byte, curve25519.PointSize)
+	uBytes := u.Bytes()
+	copy(out[curve25519.PointSize-len(uBytes):], uBytes)
+	return reverseBytes(out), nil
+}
+
+//the x25519 scalar is the same scalar used by ed25519 for signing
+func ConvertPrivateKeyToX25519(privateKey []byte) ([]byte, error) {
+
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("Invalid private key length")
+	}
+
+	h := sha512.Sum512(privateKey[:ed25519.SeedSize])
+	scalar := h[:curve25519.ScalarSize]
+	scalar[0] &= 248
+	scalar[31] &= 127
+	scalar[31] |= 64
+
+	return scalar, nil
+}
+
+func createX25519Cipher(shared, ephemeralPublicKey, publicKey []byte) (cipher.AEAD, error) {
+
+	h := sha3.New256()
+	h.Write(shared)
+	h.Write(ephemeralPublicKey)
+	h.Write(publicKey)
+
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+//encrypting the data to the ed25519 public key using an ephemeral x25519 key
+//output: ephemeral public key | nonce | ciphertext
+func EncryptX25519(publicKey, data []byte) ([]byte, error) {
+
+	publicKeyX25519, err := ConvertPublicKeyToX25519(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralPrivateKey := make([]byte, curve25519.ScalarSize)
+	if _, err = io.ReadFull(rand.Reader, ephemeralPrivateKey); err != nil {
+		return nil, err
+	}
+
+	ephemeralPublicKey, err := curve25519.X25519(ephemeralPrivateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := curve25519.X25519(ephemeralPrivateKey, publicKeyX25519)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := createX25519Cipher(shared, ephemeralPublicKey, publicKeyX25519)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(ephemeralPublicKey, nonce...)
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+func DecryptX25519(privateKey, data []byte) ([]byte, error) {
+
+	if len(data) < X25519EncryptionOverhead {
+		return nil, errors.New("Encrypted data is too short")
+	}
+
+	scalar, err := ConvertPrivateKeyToX25519(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyX25519, err := curve25519.X25519(scalar, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralPublicKey := data[:curve25519.PointSize]
+
+	shared, err := curve25519.X25519(scalar, ephemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := createX25519Cipher(shared, ephemeralPublicKey, publicKeyX25519)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := data[curve25519.PointSize : curve25519.PointSize+gcm.NonceSize()]
+	return gcm.Open(nil, nonce, data[curve25519.PointSize+gcm.NonceSize():], nil)
+}
@@ -0,0 +1,49 @@
+package encryption
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/curve25519"
+	"testing"
+)
+
+func TestX25519_ConvertKeys(t *testing.T) {
+
+	for i := 0; i < 20; i++ {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		assert.Nil(t, err)
+
+		scalar, err := ConvertPrivateKeyToX25519(privateKey)
+		assert.Nil(t, err)
+
+		expected, err := curve25519.X25519(scalar, curve25519.Basepoint)
+		assert.Nil(t, err)
+
+		converted, err := ConvertPublicKeyToX25519(publicKey)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, converted)
+	}
+}
+
+func TestX25519_EncryptDecrypt(t *testing.T) {
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	message := []byte("deposit reference 123456")
+
+	encrypted, err := EncryptX25519(publicKey, message)
+	assert.Nil(t, err)
+	assert.Equal(t, len(message)+X25519EncryptionOverhead, len(encrypted))
+
+	decrypted, err := DecryptX25519(privateKey, encrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, message, decrypted)
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	_, err = DecryptX25519(otherPrivateKey, encrypted)
+	assert.NotNil(t, err)
+}
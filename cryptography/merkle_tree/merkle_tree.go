@@ -1,13 +1,15 @@
 package merkle_tree
 
 import (
+	"bytes"
+	"errors"
 	"math"
 	"pandora-pay/cryptography"
 )
 
 /**
 Fast Merkle Tree Construction
-It can't generate efficient proofs
+The proof of a leaf contains one sibling hash for every level of the tree
 */
 
 func roundNextPowerOfTwo(number int) int {
@@ -22,7 +24,8 @@ func roundNextPowerOfTwo(number int) int {
 
 func hashMerkleNode(left []byte, right []byte) []byte {
 	// Concatenate the left and right nodes.
-	hash := append(left, right...)
+	// A new slice is required to avoid altering the left node
+	hash := append(append(make([]byte, 0, len(left)+len(right)), left...), right...)
 	return cryptography.SHA3(hash)
 }
 
@@ -65,3 +68,56 @@ func MerkleRoot(hashes [][]byte) []byte {
 	merkles := buildMerkleTree(hashes)
 	return merkles[len(merkles)-1] //return last element
 }
+
+//returns the sibling hashes from the leaf up to the root
+func MerkleProof(hashes [][]byte, index int) ([][]byte, error) {
+
+	if index < 0 || index >= len(hashes) {
+		return nil, errors.New("Merkle leaf index is invalid")
+	}
+
+	nodes := buildMerkleTree(hashes)
+
+	proof := make([][]byte, 0)
+	offset, count := 0, roundNextPowerOfTwo(len(hashes))
+	for count > 1 {
+
+		sibling := nodes[offset+(index^1)]
+		if sibling == nil { //the last node of the level is hashed with itself
+			sibling = nodes[offset+index]
+		}
+		proof = append(proof, sibling)
+
+		offset += count
+		count /= 2
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+//a right node equal to its sibling is the copy hashed with the last node of a level, hence the positions after the last leaf are rejected.
+//The leaves must be unique
+func VerifyMerkleProof(root, hash []byte, index int, proof [][]byte) bool {
+
+	if index < 0 || index >= 1<<uint(len(proof)) {
+		return false
+	}
+
+	for _, sibling := range proof {
+		if len(sibling) != cryptography.HashSize {
+			return false
+		}
+		if index%2 == 0 {
+			hash = hashMerkleNode(hash, sibling)
+		} else {
+			if bytes.Equal(sibling, hash) {
+				return false
+			}
+			hash = hashMerkleNode(sibling, hash)
+		}
+		index /= 2
+	}
+
+	return bytes.Equal(root, hash)
+}
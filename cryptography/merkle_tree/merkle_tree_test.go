@@ -18,3 +18,61 @@ func TestMerkleRoot(t *testing.T) {
 	assert.Equal(t, root, hash, "Merkle Tree Hashes are invalid")
 
 }
+
+func TestMerkleProof(t *testing.T) {
+
+	for count := 1; count < 20; count++ {
+
+		hashes := make([][]byte, count)
+		for i := range hashes {
+			hashes[i] = cryptography.RandomHash()
+		}
+
+		root := MerkleRoot(hashes)
+
+		for i := range hashes {
+			proof, err := MerkleProof(hashes, i)
+			assert.NoError(t, err)
+			assert.True(t, VerifyMerkleProof(root, hashes[i], i, proof), "Merkle Proof is invalid")
+			assert.False(t, VerifyMerkleProof(root, cryptography.RandomHash(), i, proof), "Merkle Proof should be invalid")
+			if i^1 < count {
+				assert.False(t, VerifyMerkleProof(root, hashes[i], i^1, proof), "Merkle Proof should be invalid")
+			}
+		}
+
+		_, err := MerkleProof(hashes, count)
+		assert.Error(t, err)
+	}
+
+}
+
+func TestMerkleProof_PaddedPositions(t *testing.T) {
+
+	for count := 1; count < 20; count++ {
+
+		hashes := make([][]byte, count)
+		for i := range hashes {
+			hashes[i] = cryptography.RandomHash()
+		}
+
+		root := MerkleRoot(hashes)
+
+		padded := 0
+		for i := range hashes {
+
+			proof, err := MerkleProof(hashes, i)
+			assert.NoError(t, err)
+
+			//a node hashed with itself gives the same parent on both sides, so the proof matches a position after the last leaf
+			for level := range proof {
+				if index := i | 1<<uint(level); index != i && index >= count {
+					assert.False(t, VerifyMerkleProof(root, hashes[i], index, proof), "Merkle Proof of a padded position should be invalid")
+					padded += 1
+				}
+			}
+		}
+
+		assert.Equal(t, roundNextPowerOfTwo(count) != count, padded > 0)
+	}
+
+}
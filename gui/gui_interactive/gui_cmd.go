@@ -54,14 +54,34 @@ var commands = []Command{
 	{Name: "Wallet:TX", Text: "Private Asset Supply Increase"},
 	{Name: "Wallet:TX", Text: "Private Plain Account Fund"},
 	{Name: "Wallet:TX", Text: "Update Asset Fee Liquidity"},
+	{Name: "Wallet:TX", Text: "Simple Transfer"},
+	{Name: "Wallet:TX", Text: "Sign Simple Tx"},
+	{Name: "Wallet:TX", Text: "Combine Simple Txs"},
+	{Name: "Wallet:TX", Text: "Create Unsigned Simple Transfer"},
+	{Name: "Wallet:TX", Text: "Sign Unsigned Simple Tx"},
+	{Name: "Wallet:TX", Text: "Broadcast Simple Tx"},
+	{Name: "Wallet:TX", Text: "Simple Stake"},
+	{Name: "Wallet:TX", Text: "Simple Update Delegate"},
+	{Name: "Wallet:TX", Text: "Asset Create"},
+	{Name: "Wallet:TX", Text: "Asset Supply Increase"},
+	{Name: "Wallet:TX", Text: "Asset Supply Decrease"},
+	{Name: "Wallet:TX", Text: "Asset Update"},
+	{Name: "Wallet:TX", Text: "Asset Pause"},
+	{Name: "Wallet:TX", Text: "Asset Freeze"},
 	{Name: "Wallet", Text: "Export Addresses"},
 	{Name: "Wallet", Text: "Export Address JSON"},
 	{Name: "Wallet", Text: "Import Address JSON"},
 	{Name: "Wallet", Text: "Export Wallet JSON"},
 	{Name: "Wallet", Text: "Import Wallet JSON"},
+	{Name: "Wallet", Text: "Import Watch-Only Address"},
+	{Name: "Wallet", Text: "Export Watch-Only Addresses"},
 	{Name: "Wallet", Text: "Encrypt Wallet"},
 	{Name: "Wallet", Text: "Decrypt Wallet"},
 	{Name: "Wallet", Text: "Remove Encryption"},
+	{Name: "Chain", Text: "Export Chain"},
+	{Name: "Chain", Text: "Import Chain"},
+	{Name: "Chain", Text: "Verify Store"},
+	{Name: "Chain", Text: "Reindex Info"},
 	{Name: "Mempool", Text: "Show Txs"},
 	{Name: "App", Text: "Exit"},
 }
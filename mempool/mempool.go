@@ -3,7 +3,9 @@ package mempool
 import (
 	"context"
 	"errors"
+	"pandora-pay/blockchain/data_storage/assets"
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/config/config_fees"
 	"pandora-pay/gui"
@@ -11,6 +13,8 @@ import (
 	"pandora-pay/helpers/generics"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/recovery"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
 	"pandora-pay/txs_validator"
 	"runtime"
 	"time"
@@ -43,7 +47,36 @@ func (mempool *Mempool) ContinueProcessing(continueProcessingType ContinueProces
 
 func (mempool *Mempool) RemoveInsertedTxsFromBlockchain(txs []string) bool {
 	answerCn := make(chan bool)
-	mempool.removeTransactionsCn <- &MempoolWorkerRemoveTxs{txs, answerCn}
+	mempool.removeTransactionsCn <- &MempoolWorkerRemoveTxs{txs, true, answerCn}
+	return <-answerCn
+}
+
+//evicting the txs that have vin or vout with paused assets
+func (mempool *Mempool) RemovePausedAssetsTxs(pausedAssets map[string]bool) bool {
+
+	if len(pausedAssets) == 0 {
+		return false
+	}
+
+	txs := []string{}
+	for _, tx := range mempool.Txs.GetTxsList() {
+		if tx.Tx.Version == transaction_type.TX_SIMPLE {
+			base := tx.Tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+			for assetId := range base.GetAllAssets() {
+				if pausedAssets[assetId] {
+					txs = append(txs, tx.Tx.Bloom.HashStr)
+					break
+				}
+			}
+		}
+	}
+
+	if len(txs) == 0 {
+		return false
+	}
+
+	answerCn := make(chan bool)
+	mempool.removeTransactionsCn <- &MempoolWorkerRemoveTxs{txs, false, answerCn}
 	return <-answerCn
 }
 
@@ -83,13 +116,23 @@ func (mempool *Mempool) processTxsToMempool(txs []*transaction.Transaction, heig
 		}
 
 		if errs[i] = mempool.txsValidator.ValidateTx(tx); errs[i] != nil {
-			return
+			continue
 		}
 
 		if mempool.Txs.Exists(tx.Bloom.HashStr) {
 			continue
 		}
 
+		switch tx.Version {
+		case transaction_type.TX_SIMPLE:
+			base := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+			if errs[i] = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+				return base.VerifyAssetsNotPaused(assets.NewAssets(reader))
+			}); errs[i] != nil {
+				continue
+			}
+		}
+
 		minerFee, err := tx.GetAllFee()
 		if err != nil {
 			errs[i] = err
@@ -166,17 +209,19 @@ func (mempool *Mempool) AddTxsToMempool(txs []*transaction.Transaction, height u
 	if exceptSocketUUID != advanced_connection_types.UUID_SKIP_ALL {
 
 		broadcastTxs := make([]*transaction.Transaction, 0)
-		for _, finalTx := range finalTxs {
+		broadcastIndexes := make([]int, 0)
+		for i, finalTx := range finalTxs {
 			if finalTx != nil {
 				broadcastTxs = append(broadcastTxs, finalTx.Tx)
+				broadcastIndexes = append(broadcastIndexes, i)
 			}
 		}
 
 		errors2 := mempool.OnBroadcastNewTransaction(broadcastTxs, justCreated, awaitBroadcasting, exceptSocketUUID, ctx)
 		for i, err := range errors2 {
 			if err != nil {
-				errs[i] = err
-				finalTxs[i] = nil
+				errs[broadcastIndexes[i]] = err
+				finalTxs[broadcastIndexes[i]] = nil
 			}
 		}
 
@@ -26,8 +26,9 @@ type MempoolWorkerAddTx struct {
 }
 
 type MempoolWorkerRemoveTxs struct {
-	Txs    []string
-	Result chan<- bool
+	Txs                  []string
+	IncludedInBlockchain bool
+	Result               chan<- bool
 }
 
 type MempoolWorkerInsertTxs struct {
@@ -88,7 +89,7 @@ func (worker *mempoolWorker) processing(
 			if hash != "" {
 				if tx := txsMap[hash]; tx != nil {
 					removedTxsMap[hash] = true
-					removeTxNow(tx, true, true)
+					removeTxNow(tx, true, data.IncludedInBlockchain)
 				}
 			}
 		}
@@ -39,6 +39,8 @@ type APICommon struct {
 	mempoolProcessedThisBlock *generics.Value[*generics.Map[string, *mempoolNewTxReply]]
 	temporaryList             *generics.Value[*APINetworkNodesReply]
 	temporaryListCreation     *generics.Value[time.Time]
+	batchMethods              map[string]*apiBatchMethod
+	batchMethodsRPC           map[string]*apiBatchMethod
 }
 
 //make sure it is safe to read
@@ -94,8 +96,12 @@ func NewAPICommon(knownNodes *known_nodes.KnownNodes, mempool *mempool.Mempool,
 		&generics.Value[*generics.Map[string, *mempoolNewTxReply]]{},
 		&generics.Value[*APINetworkNodesReply]{},
 		&generics.Value[time.Time]{},
+		nil,
+		nil,
 	}
 
+	api.initBatchMethods()
+
 	api.temporaryListCreation.Store(time.Now())
 
 	api.mempoolProcessedThisBlock.Store(&generics.Map[string, *mempoolNewTxReply]{})
@@ -0,0 +1,61 @@
+package api_common
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type apiViewKey struct{}
+
+//returns an error in case the chain tip moved since the client pinned it
+func checkChainPin(reader store_db_interface.StoreDBTransactionInterface, pin *api_types.APIChainPin) error {
+
+	if pin == nil || (len(pin.ChainHash) == 0 && pin.ChainHeight == 0) {
+		return nil
+	}
+
+	chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+	chainHash := reader.Get("chainHash")
+
+	if (len(pin.ChainHash) > 0 && !bytes.Equal(pin.ChainHash, chainHash)) || (pin.ChainHeight > 0 && pin.ChainHeight != chainHeight) {
+		return fmt.Errorf("Stale view. The chain tip is at height %d", chainHeight)
+	}
+
+	return nil
+}
+
+//the reader is shared by all the requests of a batch so the results are read from the same state of the chain
+func (api *APICommon) view(r *http.Request, pin *api_types.APIChainPin, callback func(reader store_db_interface.StoreDBTransactionInterface) error) error {
+
+	if r != nil {
+		if reader, ok := r.Context().Value(apiViewKey{}).(store_db_interface.StoreDBTransactionInterface); ok {
+			if err := checkChainPin(reader, pin); err != nil {
+				return err
+			}
+			return callback(reader)
+		}
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		if err := checkChainPin(reader, pin); err != nil {
+			return err
+		}
+		return callback(reader)
+	})
+}
+
+func (api *APICommon) viewBatch(pin *api_types.APIChainPin, callback func(r *http.Request) error) error {
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		if err := checkChainPin(reader, pin); err != nil {
+			return err
+		}
+		r := new(http.Request).WithContext(context.WithValue(context.Background(), apiViewKey{}, reader))
+		return callback(r)
+	})
+}
@@ -41,6 +41,9 @@ func (api *DelegatorNode) DelegatorNotify(r *http.Request, args *ApiDelegatorNod
 	sharedStakedPublicKey := sharedStakedPrivateKey.GeneratePublicKey()
 
 	addr := api.wallet.GetWalletAddressByPublicKeyHash(args.PublicKeyHash, true)
+	if addr != nil && addr.IsWatchOnly {
+		return errors.New("Address is imported as watch-only")
+	}
 	if addr != nil && addr.PrivateKey == nil {
 		reply.Result = true
 		return
@@ -91,6 +94,7 @@ func (api *DelegatorNode) DelegatorNotify(r *http.Request, args *ApiDelegatorNod
 			sharedStakedPrivateKey,
 			sharedStakedPublicKey,
 		},
+		false,
 		"",
 	}, true); err != nil {
 		return
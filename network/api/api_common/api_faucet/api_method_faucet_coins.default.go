@@ -31,13 +31,14 @@ func (api *Faucet) GetFaucetCoins(r *http.Request, args *APIFaucetCoinsRequest,
 
 	txData := &txs_builder.TxBuilderCreateSimpleTx{
 		0,
-		&wizard.WizardTransactionData{[]byte("Testnet Faucet Tx"), false},
+		&wizard.WizardTransactionData{[]byte("Testnet Faucet Tx"), false, nil},
 		&wizard.WizardTransactionFee{0, 0, 0, true},
 		nil,
 		[]*txs_builder.TxBuilderCreateSimpleTxVin{{
 			addr.AddressEncoded,
 			config_coins.ConvertToUnitsUint64Forced(100),
 			config_coins.NATIVE_ASSET_FULL,
+			nil,
 		}},
 		[]*txs_builder.TxBuilderCreateSimpleTxVout{{
 			args.Address,
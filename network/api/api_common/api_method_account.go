@@ -8,13 +8,13 @@ import (
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
-	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APIAccountRequest struct {
 	api_types.APIAccountBaseRequest
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty"  msgpack:"returnType,omitempty" `
+	api_types.APIChainPin
 }
 
 type APIAccountReply struct {
@@ -33,7 +33,7 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 		return
 	}
 
-	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	if err = api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		accsCollection := accounts.NewAccountsCollection(reader)
 		plainAccs := plain_accounts.NewPlainAccounts(reader)
@@ -4,12 +4,13 @@ import (
 	"net/http"
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/helpers"
-	"pandora-pay/store"
+	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APIAccountsCountRequest struct {
 	Asset helpers.Base64 `json:"asset" msgpack:"asset"`
+	api_types.APIChainPin
 }
 
 type APIAccountsCountReply struct {
@@ -17,7 +18,7 @@ type APIAccountsCountReply struct {
 }
 
 func (api *APICommon) GetAccountsCount(r *http.Request, args *APIAccountsCountRequest, reply *APIAccountsCountReply) error {
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 		accs, err := accounts.NewAccountsCollection(reader).GetMap(args.Asset)
 		if err != nil {
 			return
@@ -1,25 +1,40 @@
 package api_common
 
 import (
-	"errors"
 	"net/http"
+	"pandora-pay/blockchain/info"
 	"pandora-pay/config"
+	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/network/api/api_common/api_types"
-	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
 	"strconv"
 )
 
 type APIAccountTxsRequest struct {
 	api_types.APIAccountBaseRequest
-	Start uint64 `json:"start,omitempty" msgpack:"start,omitempty"`
-	Dsc   bool   `json:"dsc,omitempty" msgpack:"dsc,omitempty"`
+	Start          uint64                  `json:"start,omitempty" msgpack:"start,omitempty"`
+	Dsc            bool                    `json:"dsc,omitempty" msgpack:"dsc,omitempty"`
+	Asset          helpers.Base64          `json:"asset,omitempty" msgpack:"asset,omitempty"`
+	Direction      info.AccountTxDirection `json:"direction,omitempty" msgpack:"direction,omitempty"`
+	StartHeight    uint64                  `json:"startHeight,omitempty" msgpack:"startHeight,omitempty"`
+	EndHeight      uint64                  `json:"endHeight,omitempty" msgpack:"endHeight,omitempty"`
+	StartTimestamp uint64                  `json:"startTimestamp,omitempty" msgpack:"startTimestamp,omitempty"`
+	EndTimestamp   uint64                  `json:"endTimestamp,omitempty" msgpack:"endTimestamp,omitempty"`
+	api_types.APIChainPin
 }
 
 type APIAccountTxsReply struct {
-	Count uint64   `json:"count,omitempty" msgpack:"count,omitempty"`
-	Txs   [][]byte `json:"txs,omitempty" msgpack:"txs,omitempty"`
+	Count uint64            `json:"count,omitempty" msgpack:"count,omitempty"`
+	Txs   [][]byte          `json:"txs,omitempty" msgpack:"txs,omitempty"`
+	Infos []*info.AccountTx `json:"infos,omitempty" msgpack:"infos,omitempty"`
+	Next  uint64            `json:"next,omitempty" msgpack:"next,omitempty"` //start of the next page when filters are used
+	More  bool              `json:"more,omitempty" msgpack:"more,omitempty"`
+}
+
+func (args *APIAccountTxsRequest) hasFilters() bool {
+	return len(args.Asset) > 0 || args.Direction != info.ACCOUNT_TX_ALL || args.StartHeight > 0 || args.EndHeight > 0 || args.StartTimestamp > 0 || args.EndTimestamp > 0
 }
 
 func (api *APICommon) GetAccountTxs(r *http.Request, args *APIAccountTxsRequest, reply *APIAccountTxsReply) (err error) {
@@ -31,7 +46,7 @@ func (api *APICommon) GetAccountTxs(r *http.Request, args *APIAccountTxsRequest,
 
 	publicKeyHashStr := string(publicKeyHash)
 
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		data := reader.Get("addrTxsCount:" + publicKeyHashStr)
 		if data == nil {
@@ -42,6 +57,10 @@ func (api *APICommon) GetAccountTxs(r *http.Request, args *APIAccountTxsRequest,
 			return
 		}
 
+		if args.hasFilters() {
+			return api.getAccountTxsFiltered(reader, publicKeyHash, args, reply)
+		}
+
 		s := generics.Min(generics.Max(args.Start, 0), reply.Count)
 		if args.Dsc {
 			if s < config.API_ACCOUNT_MAX_TXS {
@@ -53,18 +72,99 @@ func (api *APICommon) GetAccountTxs(r *http.Request, args *APIAccountTxsRequest,
 		n := generics.Min(s+config.API_ACCOUNT_MAX_TXS, reply.Count)
 
 		reply.Txs = make([][]byte, n-s)
+		reply.Infos = make([]*info.AccountTx, n-s)
 		for i := 0; i < len(reply.Txs); i++ {
-			hash := reader.Get("addrTx:" + publicKeyHashStr + ":" + strconv.FormatUint(s+uint64(i), 10))
-			if hash == nil {
-				return errors.New("Error reading address transaction")
+			var accountTx *info.AccountTx
+			if accountTx, err = api.ApiStore.loadAccountTx(reader, publicKeyHash, s+uint64(i)); err != nil {
+				return
 			}
 			if args.Dsc {
-				reply.Txs[len(reply.Txs)-i-1] = hash
+				reply.Txs[len(reply.Txs)-i-1] = accountTx.Hash
+				reply.Infos[len(reply.Txs)-i-1] = accountTx
 			} else {
-				reply.Txs[i] = hash
+				reply.Txs[i] = accountTx.Hash
+				reply.Infos[i] = accountTx
 			}
 		}
 
 		return
 	})
 }
+
+//the txs of an account are stored in the order of the blocks, so the height and timestamp ranges are found using binary search
+func (api *APICommon) getAccountTxsFiltered(reader store_db_interface.StoreDBTransactionInterface, publicKeyHash []byte, args *APIAccountTxsRequest, reply *APIAccountTxsReply) (err error) {
+
+	search := func(callback func(accountTx *info.AccountTx) bool) uint64 {
+		return uint64(sort.Search(int(reply.Count), func(i int) bool {
+			if err != nil {
+				return true
+			}
+			var accountTx *info.AccountTx
+			if accountTx, err = api.ApiStore.loadAccountTx(reader, publicKeyHash, uint64(i)); err != nil {
+				return true
+			}
+			return callback(accountTx)
+		}))
+	}
+
+	start := search(func(accountTx *info.AccountTx) bool {
+		return accountTx.BlkHeight >= args.StartHeight && accountTx.Timestamp >= args.StartTimestamp
+	})
+	end := search(func(accountTx *info.AccountTx) bool {
+		return (args.EndHeight > 0 && accountTx.BlkHeight > args.EndHeight) || (args.EndTimestamp > 0 && accountTx.Timestamp > args.EndTimestamp)
+	})
+	if err != nil {
+		return
+	}
+
+	var asset []byte
+	if len(args.Asset) > 0 {
+		asset = args.Asset
+	}
+
+	reply.Txs = [][]byte{}
+	reply.Infos = []*info.AccountTx{}
+
+	//in descending order the start is exclusive and 0 means from the latest tx
+	if args.Dsc {
+		if args.Start > 0 {
+			end = generics.Min(end, args.Start)
+		}
+	} else {
+		start = generics.Max(start, args.Start)
+	}
+
+	for scanned := uint64(0); scanned < config.API_ACCOUNT_TXS_MAX_SCAN && start < end; scanned++ {
+
+		var i uint64
+		if args.Dsc {
+			end -= 1
+			i = end
+		} else {
+			i = start
+			start += 1
+		}
+
+		var accountTx *info.AccountTx
+		if accountTx, err = api.ApiStore.loadAccountTx(reader, publicKeyHash, i); err != nil {
+			return
+		}
+
+		if accountTx.Matches(asset, args.Direction) {
+			reply.Txs = append(reply.Txs, accountTx.Hash)
+			reply.Infos = append(reply.Infos, accountTx)
+			if uint64(len(reply.Txs)) == config.API_ACCOUNT_MAX_TXS {
+				break
+			}
+		}
+	}
+
+	if args.Dsc {
+		reply.Next = end
+	} else {
+		reply.Next = start
+	}
+	reply.More = start < end
+
+	return
+}
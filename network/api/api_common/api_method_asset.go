@@ -6,7 +6,6 @@ import (
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
-	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -14,6 +13,7 @@ type APIAssetRequest struct {
 	Height     uint64                  `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash       helpers.Base64          `json:"hash,omitempty" msgpack:"hash,omitempty"`
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+	api_types.APIChainPin
 }
 
 type APIAssetReply struct {
@@ -22,7 +22,7 @@ type APIAssetReply struct {
 }
 
 func (api *APICommon) GetAsset(r *http.Request, args *APIAssetRequest, reply *APIAssetReply) (err error) {
-	if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	if err := api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		if args.Hash == nil {
 			if args.Hash, err = api.ApiStore.loadAssetHash(reader, args.Height); err != nil {
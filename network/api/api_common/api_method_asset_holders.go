@@ -0,0 +1,71 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIAssetHoldersRequest struct {
+	Asset helpers.Base64 `json:"asset" msgpack:"asset"`
+	Start uint64         `json:"start,omitempty" msgpack:"start,omitempty"`
+	api_types.APIChainPin
+}
+
+type APIAssetHolder struct {
+	PublicKeyHash helpers.Base64 `json:"publicKeyHash" msgpack:"publicKeyHash"`
+	Balance       uint64         `json:"balance" msgpack:"balance"`
+}
+
+type APIAssetHoldersReply struct {
+	HoldersCount  uint64            `json:"holdersCount" msgpack:"holdersCount"`   //accounts with a positive balance
+	AccountsCount uint64            `json:"accountsCount" msgpack:"accountsCount"` //all the accounts of the asset
+	Holders       []*APIAssetHolder `json:"holders" msgpack:"holders"`
+}
+
+//the holders are sorted by balance, the biggest first
+func (api *APICommon) GetAssetHolders(r *http.Request, args *APIAssetHoldersRequest, reply *APIAssetHoldersReply) error {
+
+	if args.Start >= config.API_ASSET_HOLDERS_MAX_RANK {
+		return errors.New("Start is too big")
+	}
+
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		accs, err := accounts.NewAccountsCollection(reader).GetMap(args.Asset)
+		if err != nil {
+			return
+		}
+
+		holders := blockchain.NewAssetHolders(reader, args.Asset)
+
+		reply.AccountsCount = accs.Count
+		reply.HoldersCount = holders.GetSize()
+
+		elements, err := holders.GetTopElements(args.Start, config.API_ASSET_HOLDERS_MAX_RESULTS)
+		if err != nil {
+			return
+		}
+
+		reply.Holders = make([]*APIAssetHolder, len(elements))
+		for i, element := range elements {
+
+			acc, err := accs.GetAccount(element.Key)
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return errors.New("Holder account was not found")
+			}
+
+			reply.Holders[i] = &APIAssetHolder{element.Key, acc.Balance}
+		}
+
+		return
+	})
+}
@@ -0,0 +1,49 @@
+package api_common
+
+import (
+	"encoding/binary"
+	"net/http"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIAssetsListRequest struct {
+	Start uint64 `json:"start,omitempty" msgpack:"start,omitempty"`
+	api_types.APIChainPin
+}
+
+type APIAssetsListReply struct {
+	Count  uint64            `json:"count" msgpack:"count"`
+	Assets []*info.AssetInfo `json:"assets" msgpack:"assets"`
+}
+
+//the assets are listed in the order of their indexes
+func (api *APICommon) GetAssetsList(r *http.Request, args *APIAssetsListRequest, reply *APIAssetsListReply) error {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if data := reader.Get("assets:count"); data != nil {
+			reply.Count, _ = binary.Uvarint(data)
+		}
+
+		start := generics.Min(args.Start, reply.Count)
+		end := generics.Min(start+uint64(config.API_ASSETS_INFO_MAX_RESULTS), reply.Count)
+
+		reply.Assets = make([]*info.AssetInfo, end-start)
+		for i := range reply.Assets {
+
+			var hash []byte
+			if hash, err = api.ApiStore.loadAssetHash(reader, start+uint64(i)); err != nil {
+				return
+			}
+
+			if reply.Assets[i], err = api.ApiStore.loadAssetInfo(reader, hash); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+}
@@ -0,0 +1,77 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strings"
+)
+
+type APIAssetsSearchRequest struct {
+	Query string `json:"query" msgpack:"query"`
+	Start uint64 `json:"start,omitempty" msgpack:"start,omitempty"`
+	api_types.APIChainPin
+}
+
+type APIAssetsSearchResult struct {
+	Info       *info.AssetInfo `json:"info" msgpack:"info"`
+	SameTicker uint64          `json:"sameTicker,omitempty" msgpack:"sameTicker,omitempty"` //number of other assets using the same ticker
+}
+
+type APIAssetsSearchReply struct {
+	Count   uint64                   `json:"count" msgpack:"count"`
+	Results []*APIAssetsSearchResult `json:"results" msgpack:"results"`
+}
+
+//the query is matched against the prefixes of the ticker, of the name and of the words of the name
+func (api *APICommon) GetAssetsSearch(r *http.Request, args *APIAssetsSearchRequest, reply *APIAssetsSearchReply) error {
+
+	query := strings.Join(strings.Fields(strings.ToLower(args.Query)), " ")
+	if len(query) == 0 {
+		return errors.New("Query is empty")
+	}
+	if len(query) > 15 {
+		return errors.New("Query is too long")
+	}
+
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if reply.Count, err = blockchain.GetAssetsSearchCount(reader, query); err != nil {
+			return
+		}
+
+		start := generics.Min(args.Start, reply.Count)
+		end := generics.Min(start+uint64(config.API_ASSETS_INFO_MAX_RESULTS), reply.Count)
+
+		list, err := blockchain.GetAssetsSearch(reader, query, start, end-start)
+		if err != nil {
+			return
+		}
+
+		reply.Results = make([]*APIAssetsSearchResult, len(list))
+		for i := range reply.Results {
+
+			result := &APIAssetsSearchResult{}
+			if result.Info, err = api.ApiStore.loadAssetInfo(reader, list[i]); err != nil {
+				return
+			}
+
+			var sameTicker uint64
+			if sameTicker, err = blockchain.GetAssetsByTickerCount(reader, result.Info.Ticker); err != nil {
+				return
+			}
+			if sameTicker > 1 {
+				result.SameTicker = sameTicker - 1
+			}
+
+			reply.Results[i] = result
+		}
+
+		return
+	})
+}
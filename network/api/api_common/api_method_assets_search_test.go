@@ -0,0 +1,123 @@
+package api_common
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/http"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+)
+
+//writes the asset infos and the search index in the layout of the blockchain
+func createAssetsSearchTestStore(t *testing.T, infos []*info.AssetInfo) *store_db_memory.StoreDBMemory {
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+
+		put := func(index, term string, hash []byte) {
+			writer.Put(index+":"+strconv.Itoa(len(term))+":"+term+":"+string(hash), hash)
+			count, _ := strconv.ParseUint(string(writer.Get(index+"Count:"+term)), 10, 64)
+			writer.Put(index+"Count:"+term, []byte(strconv.FormatUint(count+1, 10)))
+		}
+
+		for _, astInfo := range infos {
+			hash := helpers.RandomBytes(cryptography.PublicKeyHashSize)
+
+			data, err := msgpack.Marshal(astInfo)
+			assert.NoError(t, err)
+			writer.Put("assetInfo_ByHash:"+string(hash), data)
+
+			for term := range blockchain.GetAssetSearchTerms(astInfo.Name, astInfo.Ticker) {
+				put("assetsSearch", term, hash)
+			}
+			put("assetsByTicker", astInfo.Ticker, hash)
+		}
+		return nil
+	}))
+
+	return db
+}
+
+func TestAPICommon_GetAssetsSearch(t *testing.T) {
+
+	infos := []*info.AssetInfo{{Name: "Pandora Pay", Ticker: "PAY"}, {Name: "Other", Ticker: "PAY"}}
+	for i := 0; i < config.API_ASSETS_INFO_MAX_RESULTS+5; i++ {
+		infos = append(infos, &info.AssetInfo{Name: "Token " + strconv.Itoa(i), Ticker: "TKN" + strconv.Itoa(i)})
+	}
+
+	db := createAssetsSearchTestStore(t, infos)
+	api := &APICommon{ApiStore: &APIStore{}}
+
+	search := func(args *APIAssetsSearchRequest) (reply *APIAssetsSearchReply, err error) {
+		reply = &APIAssetsSearchReply{}
+		err = db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			r := new(http.Request).WithContext(context.WithValue(context.Background(), apiViewKey{}, reader))
+			return api.GetAssetsSearch(r, args, reply)
+		})
+		return
+	}
+
+	//the query is case insensitive and the spaces are normalized
+	reply, err := search(&APIAssetsSearchRequest{Query: "  PANDORA   p "})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), reply.Count)
+	assert.Len(t, reply.Results, 1)
+	assert.Equal(t, "Pandora Pay", reply.Results[0].Info.Name)
+	assert.Equal(t, uint64(1), reply.Results[0].SameTicker)
+
+	reply, err = search(&APIAssetsSearchRequest{Query: "other"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), reply.Count)
+	assert.Equal(t, uint64(1), reply.Results[0].SameTicker)
+
+	//the results are paginated
+	reply, err = search(&APIAssetsSearchRequest{Query: "tok"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(config.API_ASSETS_INFO_MAX_RESULTS+5), reply.Count)
+	assert.Len(t, reply.Results, config.API_ASSETS_INFO_MAX_RESULTS)
+	assert.Equal(t, uint64(0), reply.Results[0].SameTicker)
+
+	found := make(map[string]bool)
+	for _, result := range reply.Results {
+		found[result.Info.Name] = true
+	}
+
+	reply, err = search(&APIAssetsSearchRequest{Query: "tok", Start: uint64(config.API_ASSETS_INFO_MAX_RESULTS)})
+	assert.NoError(t, err)
+	assert.Len(t, reply.Results, 5)
+	for _, result := range reply.Results {
+		assert.False(t, found[result.Info.Name], result.Info.Name)
+		found[result.Info.Name] = true
+	}
+	assert.Len(t, found, config.API_ASSETS_INFO_MAX_RESULTS+5)
+
+	reply, err = search(&APIAssetsSearchRequest{Query: "tok", Start: 1000})
+	assert.NoError(t, err)
+	assert.Empty(t, reply.Results)
+
+	reply, err = search(&APIAssetsSearchRequest{Query: "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), reply.Count)
+	assert.Empty(t, reply.Results)
+
+	_, err = search(&APIAssetsSearchRequest{Query: "   "})
+	assert.EqualError(t, err, "Query is empty")
+
+	_, err = search(&APIAssetsSearchRequest{Query: "a very long query"})
+	assert.EqualError(t, err, "Query is too long")
+
+	//a stale pin is rejected
+	_, err = search(&APIAssetsSearchRequest{Query: "pay", APIChainPin: api_types.APIChainPin{ChainHeight: 10}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package api_common
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/network/api/api_common/api_types"
+)
+
+type apiBatchMethod struct {
+	rpcName  string
+	callback func(r *http.Request, params []byte) (any, error)
+}
+
+type APIBatchRequestItem struct {
+	Method string          `json:"method" msgpack:"method"`
+	Params json.RawMessage `json:"params,omitempty" msgpack:"params,omitempty"` //json encoded arguments
+}
+
+type APIBatchRequest struct {
+	Requests []*APIBatchRequestItem `json:"requests" msgpack:"requests"`
+	api_types.APIChainPin
+}
+
+type APIBatchReplyItem struct {
+	Result any    `json:"result,omitempty" msgpack:"result,omitempty"`
+	Error  string `json:"error,omitempty" msgpack:"error,omitempty"`
+}
+
+type APIBatchReply struct {
+	Replies []*APIBatchReplyItem `json:"replies" msgpack:"replies"`
+}
+
+func batchMethod[T any, B any](rpcName string, callback func(r *http.Request, args *T, reply *B) error) *apiBatchMethod {
+	return &apiBatchMethod{rpcName, func(r *http.Request, params []byte) (any, error) {
+		args := new(T)
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, args); err != nil {
+				return nil, err
+			}
+		}
+		reply := new(B)
+		return reply, callback(r, args, reply)
+	}}
+}
+
+//only the methods reading the chain are allowed in a batch
+func (api *APICommon) initBatchMethods() {
+
+	list := map[string]*apiBatchMethod{
+		"block-hash":     batchMethod[APIBlockHashRequest, APIBlockHashReply]("GetBlockHash", api.GetBlockHash),
+		"block":          batchMethod[APIBlockRequest, APIBlockReply]("GetBlock", api.GetBlock),
+		"block-complete": batchMethod[APIBlockCompleteRequest, APIBlockCompleteReply]("GetBlockComplete", api.GetBlockComplete),
+		"tx-hash":        batchMethod[APITxHashRequest, APITxHashReply]("GetTxHash", api.GetTxHash),
+		"tx":             batchMethod[APITxRequest, APITxReply]("GetTx", api.GetTx),
+		"tx/proof":       batchMethod[APITxProofRequest, APITxProofReply]("GetTxProof", api.GetTxProof),
+		"tx-raw":         batchMethod[APITxRawRequest, APITxRawReply]("GetTxRaw", api.GetTxRaw),
+		"account":        batchMethod[APIAccountRequest, APIAccountReply]("GetAccount", api.GetAccount),
+		"accounts/count": batchMethod[APIAccountsCountRequest, APIAccountsCountReply]("GetAccountsCount", api.GetAccountsCount),
+		"asset":          batchMethod[APIAssetRequest, APIAssetReply]("GetAsset", api.GetAsset),
+	}
+
+	if config.SEED_WALLET_NODES_INFO {
+		list["asset-info"] = batchMethod[APIAssetInfoRequest, info.AssetInfo]("GetAssetInfo", api.GetAssetInfo)
+		list["block-info"] = batchMethod[APIBlockInfoRequest, info.BlockInfo]("GetBlockInfo", api.GetBlockInfo)
+		list["tx-info"] = batchMethod[APITransactionInfoRequest, info.TxInfo]("GetTxInfo", api.GetTxInfo)
+		list["tx-preview"] = batchMethod[APITransactionPreviewRequest, APITransactionPreviewReply]("GetTxPreview", api.GetTxPreview)
+		list["account/txs"] = batchMethod[APIAccountTxsRequest, APIAccountTxsReply]("GetAccountTxs", api.GetAccountTxs)
+		list["assets/list"] = batchMethod[APIAssetsListRequest, APIAssetsListReply]("GetAssetsList", api.GetAssetsList)
+		list["assets/search"] = batchMethod[APIAssetsSearchRequest, APIAssetsSearchReply]("GetAssetsSearch", api.GetAssetsSearch)
+		list["asset/holders"] = batchMethod[APIAssetHoldersRequest, APIAssetHoldersReply]("GetAssetHolders", api.GetAssetHolders)
+		list["forger/stats"] = batchMethod[APIForgerStatsRequest, APIForgerStatsReply]("GetForgerStats", api.GetForgerStats)
+		list["forger/blocks"] = batchMethod[APIForgerBlocksRequest, APIForgerBlocksReply]("GetForgerBlocks", api.GetForgerBlocks)
+	}
+
+	api.batchMethods = list
+	api.batchMethodsRPC = make(map[string]*apiBatchMethod)
+	for _, method := range list {
+		api.batchMethodsRPC[method.rpcName] = method
+	}
+}
+
+func (api *APICommon) batch(requests []*APIBatchRequestItem, pin *api_types.APIChainPin, methods map[string]*apiBatchMethod) ([]*APIBatchReplyItem, error) {
+
+	if len(requests) == 0 {
+		return nil, errors.New("No requests")
+	}
+	if len(requests) > config.API_BATCH_MAX_REQUESTS {
+		return nil, errors.New("Too many requests")
+	}
+
+	replies := make([]*APIBatchReplyItem, len(requests))
+
+	return replies, api.viewBatch(pin, func(r *http.Request) error {
+		for i, request := range requests {
+
+			replies[i] = &APIBatchReplyItem{}
+
+			method := methods[request.Method]
+			if method == nil {
+				replies[i].Error = "Method is not allowed in a batch"
+				continue
+			}
+
+			result, err := method.callback(r, request.Params)
+			if err != nil {
+				replies[i].Error = err.Error()
+				continue
+			}
+			replies[i].Result = result
+		}
+		return nil
+	})
+}
+
+//all the requests are processed using the same state of the chain. Each request has its own result or error
+func (api *APICommon) Batch(r *http.Request, args *APIBatchRequest, reply *APIBatchReply) (err error) {
+	reply.Replies, err = api.batch(args.Requests, &args.APIChainPin, api.batchMethods)
+	return
+}
+
+//the methods are identified by the json rpc names, for instance GetBlock
+func (api *APICommon) BatchRPC(requests []*APIBatchRequestItem) ([]*APIBatchReplyItem, error) {
+	return api.batch(requests, nil, api.batchMethodsRPC)
+}
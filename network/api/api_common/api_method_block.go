@@ -6,7 +6,6 @@ import (
 	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
-	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -15,17 +14,19 @@ type APIBlockRequest struct {
 	Height     uint64                  `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash       helpers.Base64          `json:"hash,omitempty" msgpack:"hash,omitempty"`
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+	api_types.APIChainPin
 }
 
 type APIBlockReply struct {
 	Block           *block.Block `json:"block,omitempty" msgpack:"block,omitempty"`
 	BlockSerialized []byte       `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
 	Txs             [][]byte     `json:"txs,omitempty" msgpack:"txs,omitempty"`
+	Pruned          bool         `json:"pruned,omitempty" msgpack:"pruned,omitempty"`
 }
 
 func (api *APICommon) GetBlock(r *http.Request, args *APIBlockRequest, reply *APIBlockReply) error {
 
-	if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	if err := api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		if len(args.Hash) == 0 {
 			if args.Hash, err = api.ApiStore.chain.LoadBlockHash(reader, args.Height); err != nil {
@@ -37,6 +38,12 @@ func (api *APICommon) GetBlock(r *http.Request, args *APIBlockRequest, reply *AP
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		//the header is kept for the pruned blocks
+		if api.ApiStore.checkBlockPruned(reader, reply.Block.Height) != nil {
+			reply.Pruned = true
+			return
+		}
+
 		txHashes := [][]byte{}
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.Block.Height, 10))
 		if err = msgpack.Unmarshal(data, &txHashes); err != nil {
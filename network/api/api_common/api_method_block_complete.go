@@ -8,7 +8,6 @@ import (
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
-	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -17,6 +16,7 @@ type APIBlockCompleteRequest struct {
 	Height     uint64                  `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash       helpers.Base64          `json:"hash,omitempty" msgpack:"hash,omitempty"`
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+	api_types.APIChainPin
 }
 
 type APIBlockCompleteReply struct {
@@ -26,7 +26,7 @@ type APIBlockCompleteReply struct {
 
 func (api *APICommon) GetBlockComplete(r *http.Request, args *APIBlockCompleteRequest, reply *APIBlockCompleteReply) error {
 
-	if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	if err := api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 		if len(args.Hash) == 0 {
 			args.Hash, err = api.ApiStore.chain.LoadBlockHash(reader, args.Height)
 		}
@@ -37,6 +37,10 @@ func (api *APICommon) GetBlockComplete(r *http.Request, args *APIBlockCompleteRe
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		if err = api.ApiStore.checkBlockPruned(reader, reply.BlockComplete.Block.Height); err != nil {
+			return
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.BlockComplete.Block.Height, 10))
 		if data == nil {
 			return errors.New("Strange. blockTxs was not found")
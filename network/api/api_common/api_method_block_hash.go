@@ -2,10 +2,13 @@ package api_common
 
 import (
 	"net/http"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APIBlockHashRequest struct {
 	Height uint64 `json:"height" msgpack:"height"`
+	api_types.APIChainPin
 }
 
 type APIBlockHashReply struct {
@@ -13,6 +16,8 @@ type APIBlockHashReply struct {
 }
 
 func (api *APICommon) GetBlockHash(r *http.Request, args *APIBlockHashRequest, reply *APIBlockHashReply) (err error) {
-	reply.Hash, err = api.ApiStore.chain.OpenLoadBlockHash(args.Height)
-	return
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		reply.Hash, err = api.ApiStore.chain.LoadBlockHash(reader, args.Height)
+		return
+	})
 }
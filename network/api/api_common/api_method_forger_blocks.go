@@ -0,0 +1,83 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIForgerBlocksRequest struct {
+	api_types.APIAccountBaseRequest
+	Start       uint64 `json:"start,omitempty" msgpack:"start,omitempty"`
+	Dsc         bool   `json:"dsc,omitempty" msgpack:"dsc,omitempty"`
+	StartHeight uint64 `json:"startHeight,omitempty" msgpack:"startHeight,omitempty"`
+	EndHeight   uint64 `json:"endHeight,omitempty" msgpack:"endHeight,omitempty"`
+	api_types.APIChainPin
+}
+
+type APIForgerBlocksReply struct {
+	Count  uint64              `json:"count,omitempty" msgpack:"count,omitempty"`
+	Blocks []*info.ForgerBlock `json:"blocks,omitempty" msgpack:"blocks,omitempty"`
+	Next   uint64              `json:"next,omitempty" msgpack:"next,omitempty"` //start of the next page
+	More   bool                `json:"more,omitempty" msgpack:"more,omitempty"`
+}
+
+func (api *APICommon) GetForgerBlocks(r *http.Request, args *APIForgerBlocksRequest, reply *APIForgerBlocksReply) (err error) {
+
+	publicKeyHash, err := args.GetPublicKeyHash(true)
+	if err != nil {
+		return
+	}
+
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if reply.Count, err = info.LoadForgerBlocksCount(reader, publicKeyHash); err != nil || reply.Count == 0 {
+			return
+		}
+
+		var start, end uint64
+		if start, end, err = api.searchForgerBlocks(reader, publicKeyHash, reply.Count, args.StartHeight, args.EndHeight); err != nil {
+			return
+		}
+
+		//in descending order the start is exclusive and 0 means from the latest block
+		if args.Dsc {
+			if args.Start > 0 {
+				end = generics.Min(end, args.Start)
+			}
+		} else {
+			start = generics.Max(start, args.Start)
+		}
+
+		reply.Blocks = []*info.ForgerBlock{}
+		for start < end && uint64(len(reply.Blocks)) < config.API_FORGER_BLOCKS_MAX_RESULTS {
+
+			var i uint64
+			if args.Dsc {
+				end -= 1
+				i = end
+			} else {
+				i = start
+				start += 1
+			}
+
+			var forgerBlock *info.ForgerBlock
+			if forgerBlock, err = info.LoadForgerBlock(reader, publicKeyHash, i); err != nil {
+				return
+			}
+			reply.Blocks = append(reply.Blocks, forgerBlock)
+		}
+
+		if args.Dsc {
+			reply.Next = end
+		} else {
+			reply.Next = start
+		}
+		reply.More = start < end
+
+		return
+	})
+}
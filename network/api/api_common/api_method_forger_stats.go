@@ -0,0 +1,115 @@
+package api_common
+
+import (
+	"encoding/binary"
+	"math/big"
+	"net/http"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
+)
+
+type APIForgerStatsRequest struct {
+	api_types.APIAccountBaseRequest
+	StartHeight uint64 `json:"startHeight,omitempty" msgpack:"startHeight,omitempty"`
+	EndHeight   uint64 `json:"endHeight,omitempty" msgpack:"endHeight,omitempty"`
+	api_types.APIChainPin
+}
+
+type APIForgerStatsReply struct {
+	BlocksCount                uint64 `json:"blocksCount" msgpack:"blocksCount"` //all the blocks forged
+	Blocks                     uint64 `json:"blocks" msgpack:"blocks"`           //blocks forged in the height range
+	ChainBlocks                uint64 `json:"chainBlocks" msgpack:"chainBlocks"` //blocks of the chain in the height range
+	Reward                     uint64 `json:"reward" msgpack:"reward"`
+	Fees                       uint64 `json:"fees" msgpack:"fees"`
+	Commission                 uint64 `json:"commission" msgpack:"commission"`
+	EffectiveDelegatedStakeFee uint64 `json:"effectiveDelegatedStakeFee" msgpack:"effectiveDelegatedStakeFee"`
+	FirstBlkHeight             uint64 `json:"firstBlkHeight,omitempty" msgpack:"firstBlkHeight,omitempty"`
+	LastBlkHeight              uint64 `json:"lastBlkHeight,omitempty" msgpack:"lastBlkHeight,omitempty"`
+}
+
+//the blocks of a forger are stored in the order of the heights, so the height range is found using binary search
+func (api *APICommon) searchForgerBlocks(reader store_db_interface.StoreDBTransactionInterface, forger []byte, count, startHeight, endHeight uint64) (start, end uint64, err error) {
+
+	search := func(callback func(forgerBlock *info.ForgerBlock) bool) uint64 {
+		return uint64(sort.Search(int(count), func(i int) bool {
+			if err != nil {
+				return true
+			}
+			var forgerBlock *info.ForgerBlock
+			if forgerBlock, err = info.LoadForgerBlock(reader, forger, uint64(i)); err != nil {
+				return true
+			}
+			return callback(forgerBlock)
+		}))
+	}
+
+	start = search(func(forgerBlock *info.ForgerBlock) bool {
+		return forgerBlock.BlkHeight >= startHeight
+	})
+	end = search(func(forgerBlock *info.ForgerBlock) bool {
+		return endHeight > 0 && forgerBlock.BlkHeight > endHeight
+	})
+	return
+}
+
+func (api *APICommon) GetForgerStats(r *http.Request, args *APIForgerStatsRequest, reply *APIForgerStatsReply) (err error) {
+
+	publicKeyHash, err := args.GetPublicKeyHash(true)
+	if err != nil {
+		return
+	}
+
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+		if args.EndHeight > 0 && args.EndHeight+1 < chainHeight {
+			chainHeight = args.EndHeight + 1
+		}
+		if chainHeight > args.StartHeight {
+			reply.ChainBlocks = chainHeight - args.StartHeight
+		}
+
+		if reply.BlocksCount, err = info.LoadForgerBlocksCount(reader, publicKeyHash); err != nil || reply.BlocksCount == 0 {
+			return
+		}
+
+		var start, end uint64
+		if start, end, err = api.searchForgerBlocks(reader, publicKeyHash, reply.BlocksCount, args.StartHeight, args.EndHeight); err != nil || start >= end {
+			return
+		}
+
+		var first, last, prev *info.ForgerBlock
+		if first, err = info.LoadForgerBlock(reader, publicKeyHash, start); err != nil {
+			return
+		}
+		if last, err = info.LoadForgerBlock(reader, publicKeyHash, end-1); err != nil {
+			return
+		}
+
+		//the totals are cumulative, so the totals before the range are subtracted
+		reply.Reward, reply.Fees, reply.Commission = last.TotalReward, last.TotalFees, last.TotalCommission
+		if start > 0 {
+			if prev, err = info.LoadForgerBlock(reader, publicKeyHash, start-1); err != nil {
+				return
+			}
+			reply.Reward -= prev.TotalReward
+			reply.Fees -= prev.TotalFees
+			reply.Commission -= prev.TotalCommission
+		}
+
+		reply.Blocks = end - start
+		reply.FirstBlkHeight = first.BlkHeight
+		reply.LastBlkHeight = last.BlkHeight
+
+		final := new(big.Int).Add(new(big.Int).SetUint64(reply.Reward), new(big.Int).SetUint64(reply.Fees))
+		if final.Sign() > 0 {
+			fee := new(big.Int).Mul(new(big.Int).SetUint64(reply.Commission), new(big.Int).SetUint64(config_stake.DELEGATING_STAKING_FEE_MAX_VALUE))
+			reply.EffectiveDelegatedStakeFee = fee.Div(fee, final).Uint64()
+		}
+
+		return
+	})
+}
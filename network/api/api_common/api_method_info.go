@@ -3,13 +3,16 @@ package api_common
 import (
 	"net/http"
 	"pandora-pay/config"
+	"pandora-pay/store"
 )
 
 type APIInfoReply struct {
-	Name       string `json:"name" msgpack:"name"`
-	Version    string `json:"version" msgpack:"version"`
-	Network    uint64 `json:"network" msgpack:"network"`
-	CPUThreads int    `json:"CPUThreads" msgpack:"CPUThreads"`
+	Name       string            `json:"name" msgpack:"name"`
+	Version    string            `json:"version" msgpack:"version"`
+	Network    uint64            `json:"network" msgpack:"network"`
+	CPUThreads int               `json:"CPUThreads" msgpack:"CPUThreads"`
+	DataPath   string            `json:"dataPath" msgpack:"dataPath"`
+	Stores     map[string]string `json:"stores" msgpack:"stores"` //store name => path, empty for the stores kept in memory
 }
 
 func (api *APICommon) GetInfo(r *http.Request, args *struct{}, reply *APIInfoReply) error {
@@ -17,5 +20,12 @@ func (api *APICommon) GetInfo(r *http.Request, args *struct{}, reply *APIInfoRep
 	reply.Version = config.VERSION_STRING
 	reply.Network = config.NETWORK_SELECTED
 	reply.CPUThreads = config.CPU_THREADS
+	reply.DataPath = config.DATA_PATH
+	reply.Stores = make(map[string]string)
+	for _, s := range []*store.Store{store.StoreBlockchain, store.StoreWallet, store.StoreSettings, store.StoreMempool} {
+		if s != nil {
+			reply.Stores[s.Name] = s.Path
+		}
+	}
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/network/websocks/connection"
 )
 
@@ -44,7 +45,7 @@ func (api *APICommon) mempoolNewTxIdProcess(conn *connection.AdvancedConnection,
 		close(processedAlreadyFound.wait)
 	}()
 
-	result, err := connection.SendJSONAwaitAnswer[APITxRawReply](conn, []byte("tx-raw"), &APITxRawRequest{0, hash}, nil, 0)
+	result, err := connection.SendJSONAwaitAnswer[APITxRawReply](conn, []byte("tx-raw"), &APITxRawRequest{0, hash, api_types.APIChainPin{}}, nil, 0)
 	if err != nil {
 		closeConnection = true
 		return
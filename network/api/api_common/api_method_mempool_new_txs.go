@@ -0,0 +1,70 @@
+package api_common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"sync"
+)
+
+type APIMempoolNewTxsRequest struct {
+	Txs []helpers.Base64 `json:"txs" msgpack:"txs"`
+}
+
+type APIMempoolNewTxsReply struct {
+	Results []bool   `json:"results" msgpack:"results"`
+	Errors  []string `json:"errors" msgpack:"errors"` //empty string when the tx was accepted
+}
+
+//every tx is processed independently and the reply contains the result of each tx
+func (api *APICommon) MempoolNewTxs(r *http.Request, args *APIMempoolNewTxsRequest, reply *APIMempoolNewTxsReply) error {
+
+	if len(args.Txs) == 0 {
+		return errors.New("No txs")
+	}
+	if len(args.Txs) > config.API_MEMPOOL_NEW_TXS_MAX {
+		return errors.New("Too many txs")
+	}
+
+	reply.Results = make([]bool, len(args.Txs))
+	reply.Errors = make([]string, len(args.Txs))
+
+	txs := make([]*transaction.Transaction, 0, len(args.Txs))
+	indexes := make([]int, 0, len(args.Txs))
+
+	for i, data := range args.Txs {
+		tx := &transaction.Transaction{}
+		if err := tx.Deserialize(helpers.NewBufferReader(data)); err != nil {
+			reply.Errors[i] = err.Error()
+			continue
+		}
+		txs = append(txs, tx)
+		indexes = append(indexes, i)
+	}
+
+	//the txs are validated in parallel, the results are cached by the validator
+	wg := &sync.WaitGroup{}
+	for _, tx := range txs {
+		wg.Add(1)
+		go func(tx *transaction.Transaction) {
+			defer wg.Done()
+			api.txsValidator.ValidateTx(tx)
+		}(tx)
+	}
+	wg.Wait()
+
+	errs := api.mempool.AddTxsToMempool(txs, api.chain.GetChainData().Height, false, true, false, advanced_connection_types.UUID_ALL, context.Background())
+	for i, err := range errs {
+		if err != nil {
+			reply.Errors[indexes[i]] = err.Error()
+		} else {
+			reply.Results[indexes[i]] = true
+		}
+	}
+
+	return nil
+}
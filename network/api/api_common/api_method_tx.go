@@ -11,7 +11,6 @@ import (
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
-	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -19,6 +18,7 @@ type APITxRequest struct {
 	Height     uint64                  `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash       helpers.Base64          `json:"hash,omitempty" msgpack:"hash,omitempty"`
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+	api_types.APIChainPin
 }
 
 type APITxReply struct {
@@ -29,8 +29,8 @@ type APITxReply struct {
 	Confirmations uint64                   `json:"confirmations,omitempty" msgpack:"confirmations,omitempty"`
 }
 
-func (api *APICommon) openLoadTx(args *APITxRequest, reply *APITxReply) error {
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+func (api *APICommon) openLoadTx(r *http.Request, args *APITxRequest, reply *APITxReply) error {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		if len(args.Hash) == 0 {
 			if args.Hash, err = api.ApiStore.loadTxHash(reader, args.Height); err != nil {
@@ -42,7 +42,7 @@ func (api *APICommon) openLoadTx(args *APITxRequest, reply *APITxReply) error {
 		var data []byte
 
 		if data = reader.Get("tx:" + hashStr); data == nil {
-			return errors.New("Tx not found")
+			return api.ApiStore.checkTxPruned(reader, args.Hash)
 		}
 
 		if args.ReturnType == api_types.RETURN_SERIALIZED {
@@ -94,5 +94,5 @@ func (api *APICommon) GetTx(r *http.Request, args *APITxRequest, reply *APITxRep
 		}
 	}
 
-	return api.openLoadTx(args, reply)
+	return api.openLoadTx(r, args, reply)
 }
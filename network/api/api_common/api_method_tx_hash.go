@@ -2,12 +2,13 @@ package api_common
 
 import (
 	"net/http"
-	"pandora-pay/store"
+	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APITxHashRequest struct {
 	Height uint64 `json:"height" msgpack:"height"`
+	api_types.APIChainPin
 }
 
 type APITxHashReply struct {
@@ -15,7 +16,7 @@ type APITxHashReply struct {
 }
 
 func (api *APICommon) GetTxHash(r *http.Request, args *APITxHashRequest, reply *APITxHashReply) (err error) {
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 		reply.Hash, err = api.ApiStore.loadTxHash(reader, args.Height)
 		return
 	})
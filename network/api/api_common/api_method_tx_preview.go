@@ -5,13 +5,14 @@ import (
 	"pandora-pay/blockchain/info"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
-	"pandora-pay/store"
+	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APITransactionPreviewRequest struct {
 	Height uint64         `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash   helpers.Base64 `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	api_types.APIChainPin
 }
 
 type APITransactionPreviewReply struct {
@@ -20,21 +21,21 @@ type APITransactionPreviewReply struct {
 	Info      *info.TxInfo    `json:"info,omitempty" msgpack:"info,omitempty"`
 }
 
-func (apiStore *APIStore) openLoadTxPreview(args *APITransactionPreviewRequest, reply *APITransactionPreviewReply) error {
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+func (api *APICommon) openLoadTxPreview(r *http.Request, args *APITransactionPreviewRequest, reply *APITransactionPreviewReply) error {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		if len(args.Hash) == 0 {
-			if args.Hash, err = apiStore.loadTxHash(reader, args.Height); err != nil {
+			if args.Hash, err = api.ApiStore.loadTxHash(reader, args.Height); err != nil {
 				return
 			}
 		}
 
 		reply.TxPreview = &info.TxPreview{}
-		if err = apiStore.loadTxPreview(reader, args.Hash, reply.TxPreview); err != nil {
+		if err = api.ApiStore.loadTxPreview(reader, args.Hash, reply.TxPreview); err != nil {
 			return
 		}
 		reply.Info = &info.TxInfo{}
-		return apiStore.loadTxInfo(reader, args.Hash, reply.Info)
+		return api.ApiStore.loadTxInfo(reader, args.Hash, reply.Info)
 	})
 }
 
@@ -48,10 +49,10 @@ func (api *APICommon) GetTxPreview(r *http.Request, args *APITransactionPreviewR
 				return
 			}
 		} else {
-			err = api.ApiStore.openLoadTxPreview(args, reply)
+			err = api.openLoadTxPreview(r, args, reply)
 		}
 	} else {
-		err = api.ApiStore.openLoadTxPreview(args, reply)
+		err = api.openLoadTxPreview(r, args, reply)
 	}
 
 	return
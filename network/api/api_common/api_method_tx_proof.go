@@ -0,0 +1,126 @@
+package api_common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/http"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APITxProofRequest struct {
+	Hash helpers.Base64 `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	api_types.APIChainPin
+}
+
+type APITxProofReply struct {
+	Hash            helpers.Base64   `json:"hash" msgpack:"hash"`
+	BlockHeight     uint64           `json:"blockHeight" msgpack:"blockHeight"`
+	BlockHash       helpers.Base64   `json:"blockHash" msgpack:"blockHash"`
+	BlockSerialized helpers.Base64   `json:"blockSerialized" msgpack:"blockSerialized"`
+	MerkleHash      helpers.Base64   `json:"merkleHash" msgpack:"merkleHash"`
+	Index           int              `json:"index" msgpack:"index"`
+	TxsCount        int              `json:"txsCount" msgpack:"txsCount"`
+	Proof           []helpers.Base64 `json:"proof" msgpack:"proof"`
+}
+
+func (api *APICommon) GetTxProof(r *http.Request, args *APITxProofRequest, reply *APITxProofReply) error {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("txBlock:" + string(args.Hash))
+		if data == nil {
+			return errors.New("Tx was not found in a block")
+		}
+
+		blockHeight, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("Tx block height is invalid")
+		}
+
+		if reply.BlockHash, err = api.ApiStore.chain.LoadBlockHash(reader, blockHeight); err != nil {
+			return
+		}
+
+		blk, err := api.ApiStore.loadBlock(reader, reply.BlockHash)
+		if err != nil {
+			return
+		}
+
+		if err = api.ApiStore.checkBlockPruned(reader, blockHeight); err != nil {
+			return
+		}
+
+		txHashes := [][]byte{}
+		if err = msgpack.Unmarshal(reader.Get("blockTxs"+strconv.FormatUint(blockHeight, 10)), &txHashes); err != nil {
+			return
+		}
+
+		index := -1
+		for i, txHash := range txHashes {
+			if string(txHash) == string(args.Hash) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return errors.New("Tx was not found in the block")
+		}
+
+		proof, err := merkle_tree.MerkleProof(txHashes, index)
+		if err != nil {
+			return
+		}
+
+		reply.Hash = args.Hash
+		reply.BlockHeight = blockHeight
+		reply.BlockSerialized = helpers.SerializeToBytes(blk)
+		reply.MerkleHash = blk.MerkleHash
+		reply.Index = index
+		reply.TxsCount = len(txHashes)
+		reply.Proof = make([]helpers.Base64, len(proof))
+		for i := range proof {
+			reply.Proof[i] = proof[i]
+		}
+
+		return
+	})
+}
+
+//verifies that the tx is included in the block with the given hash
+func (reply *APITxProofReply) Verify(blockHash []byte) error {
+
+	blk := block.CreateEmptyBlock()
+	if err := blk.Deserialize(helpers.NewBufferReader(reply.BlockSerialized)); err != nil {
+		return err
+	}
+	if err := blk.BloomNow(); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(blk.Bloom.Hash, blockHash) {
+		return errors.New("Block hash is not matching")
+	}
+	if blk.Height != reply.BlockHeight {
+		return errors.New("Block height is not matching")
+	}
+	if reply.Index >= reply.TxsCount {
+		return errors.New("Tx index is invalid")
+	}
+
+	proof := make([][]byte, len(reply.Proof))
+	for i := range reply.Proof {
+		proof[i] = reply.Proof[i]
+	}
+
+	if !merkle_tree.VerifyMerkleProof(blk.MerkleHash, reply.Hash, reply.Index, proof) {
+		return errors.New("Tx merkle proof is invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package api_common
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/helpers"
+	"testing"
+)
+
+func TestAPITxProofReply_Verify(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.NoError(t, err)
+
+	//the last tx is hashed with itself
+	txHashes := [][]byte{cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash()}
+
+	blk := &block.Block{
+		BlockHeader:             &block.BlockHeader{Version: block.GetBlockVersion(10), Height: 10},
+		MerkleHash:              merkle_tree.MerkleRoot(txHashes),
+		PrevHash:                cryptography.RandomHash(),
+		PrevKernelHash:          cryptography.RandomHash(),
+		StakingAmount:           1000,
+		Timestamp:               1000,
+		Forger:                  privateKey.GeneratePublicKeyHash(),
+		DelegatedStakePublicKey: privateKey.GeneratePublicKey(),
+	}
+	if blk.HasStateRoot() {
+		blk.StateRoot = cryptography.RandomHash()
+	}
+
+	signature, err := privateKey.Sign(blk.SerializeForSigning())
+	assert.NoError(t, err)
+	blk.Signature = signature
+	assert.NoError(t, blk.BloomNow())
+
+	createReply := func(index int) *APITxProofReply {
+		proof, err := merkle_tree.MerkleProof(txHashes, index)
+		assert.NoError(t, err)
+
+		reply := &APITxProofReply{
+			Hash:            txHashes[index],
+			BlockHeight:     blk.Height,
+			BlockHash:       blk.Bloom.Hash,
+			BlockSerialized: helpers.SerializeToBytes(blk),
+			MerkleHash:      blk.MerkleHash,
+			Index:           index,
+			TxsCount:        len(txHashes),
+			Proof:           make([]helpers.Base64, len(proof)),
+		}
+		for i := range proof {
+			reply.Proof[i] = proof[i]
+		}
+		return reply
+	}
+
+	for i := range txHashes {
+		assert.NoError(t, createReply(i).Verify(blk.Bloom.Hash))
+	}
+
+	reply := createReply(2)
+	assert.EqualError(t, reply.Verify(cryptography.RandomHash()), "Block hash is not matching")
+
+	//the proof of the last tx at the padded position after it
+	reply.Index = 3
+	assert.EqualError(t, reply.Verify(blk.Bloom.Hash), "Tx index is invalid")
+	reply.TxsCount = 4
+	assert.EqualError(t, reply.Verify(blk.Bloom.Hash), "Tx merkle proof is invalid")
+
+	reply = createReply(1)
+	reply.Index = 0
+	assert.EqualError(t, reply.Verify(blk.Bloom.Hash), "Tx merkle proof is invalid")
+}
@@ -1,25 +1,25 @@
 package api_common
 
 import (
-	"errors"
 	"net/http"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
-	"pandora-pay/store"
+	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APITxRawRequest struct {
 	Height uint64         `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash   helpers.Base64 `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	api_types.APIChainPin
 }
 
 type APITxRawReply struct {
 	Tx []byte `json:"tx" msgpack:"tx"`
 }
 
-func (api *APICommon) openLoadTxOnly(args *APITxRawRequest, reply *APITxRawReply) error {
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+func (api *APICommon) openLoadTxOnly(r *http.Request, args *APITxRawRequest, reply *APITxRawReply) error {
+	return api.view(r, &args.APIChainPin, func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		if len(args.Hash) == 0 {
 			if args.Hash, err = api.ApiStore.loadTxHash(reader, args.Height); err != nil {
@@ -30,7 +30,7 @@ func (api *APICommon) openLoadTxOnly(args *APITxRawRequest, reply *APITxRawReply
 		hashStr := string(args.Hash)
 
 		if reply.Tx = reader.Get("tx:" + hashStr); reply.Tx == nil {
-			return errors.New("Tx not found")
+			return api.ApiStore.checkTxPruned(reader, args.Hash)
 		}
 
 		return
@@ -47,5 +47,5 @@ func (api *APICommon) GetTxRaw(r *http.Request, args *APITxRawRequest, reply *AP
 		}
 	}
 
-	return api.openLoadTxOnly(args, reply)
+	return api.openLoadTxOnly(r, args, reply)
 }
@@ -0,0 +1,41 @@
+package api_common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/helpers"
+	"pandora-pay/txs_builder"
+	"pandora-pay/txs_builder/wizard"
+)
+
+type APIWalletCreateUnsignedTxRequest struct {
+	TxScript transaction_simple.ScriptType `json:"txScript" msgpack:"txScript"`
+	txs_builder.TxBuilderCreateSimpleTx
+}
+
+type APIWalletCreateUnsignedTxReply struct {
+	Tx   helpers.Base64 `json:"tx" msgpack:"tx"`     //serialized without the signatures
+	Hash helpers.Base64 `json:"hash" msgpack:"hash"` //hash that needs to be signed by every vin
+}
+
+func (api *APICommon) WalletCreateUnsignedTx(r *http.Request, args *APIWalletCreateUnsignedTxRequest, reply *APIWalletCreateUnsignedTxReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if args.Extra, err = wizard.ConvertSimpleTxExtra(args.TxScript, args.Extra); err != nil {
+		return
+	}
+
+	tx, err := api.txsBuilder.CreateUnsignedSimpleTx(&args.TxBuilderCreateSimpleTx, context.Background(), func(string) {})
+	if err != nil {
+		return
+	}
+
+	reply.Tx = wizard.SerializeUnsignedTx(tx)
+	reply.Hash = tx.SerializeForSigning()
+	return
+}
@@ -36,7 +36,9 @@ func (api *APICommon) GetWalletDecryptTx(r *http.Request, args *APIWalletDecrypt
 	var txSerialized []byte
 	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
-		txSerialized = reader.Get("tx:" + string(args.Hash))
+		if txSerialized = reader.Get("tx:" + string(args.Hash)); txSerialized == nil && reader.Exists("txBlock:"+string(args.Hash)) {
+			return errors.New("Tx was pruned")
+		}
 
 		if data := reader.Get("txBlock:" + string(args.Hash)); data != nil {
 			var blockHeight, chainHeight uint64
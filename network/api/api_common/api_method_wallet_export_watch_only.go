@@ -0,0 +1,20 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/wallet/wallet_address"
+)
+
+type APIWalletExportWatchOnlyReply struct {
+	Addresses []*wallet_address.WalletAddressWatchOnlyExported `json:"addresses" msgpack:"addresses"`
+}
+
+func (api *APICommon) GetWalletExportWatchOnly(r *http.Request, args *struct{}, reply *APIWalletExportWatchOnlyReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Addresses = api.wallet.ExportWatchOnlyAddresses(true)
+	return nil
+}
@@ -41,7 +41,7 @@ func (api *APICommon) GetWalletGenerateAddress(r *http.Request, args *APIWalletG
 
 		var addr *addresses.Address
 
-		if addr, err = walletAddr.PrivateKey.GenerateAddress(args.PaymentID, args.PaymentAmount, args.PaymentAsset); err != nil {
+		if addr, err = addresses.CreateAddr(walletAddr.PublicKeyHash, args.PaymentID, args.PaymentAmount, args.PaymentAsset); err != nil {
 			return
 		}
 
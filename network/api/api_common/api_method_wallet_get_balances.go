@@ -23,9 +23,10 @@ type APIWalletGetBalancesReply struct {
 }
 
 type APIWalletGetBalancesResultReply struct {
-	Address  string                          `json:"address" msgpack:"address"`
-	PlainAcc *plain_account.PlainAccount     `json:"plainAcc" msgpack:"plainAcc"`
-	Balances []*APIWalletGetBalanceDataReply `json:"balances" msgpack:"balances"`
+	Address   string                          `json:"address" msgpack:"address"`
+	WatchOnly bool                            `json:"watchOnly" msgpack:"watchOnly"`
+	PlainAcc  *plain_account.PlainAccount     `json:"plainAcc" msgpack:"plainAcc"`
+	Balances  []*APIWalletGetBalanceDataReply `json:"balances" msgpack:"balances"`
 }
 
 type APIWalletGetBalanceDataReply struct {
@@ -64,6 +65,7 @@ func (api *APICommon) GetWalletBalances(r *http.Request, args *APIWalletGetBalan
 			reply.Results[i] = &APIWalletGetBalancesResultReply{}
 
 			reply.Results[i].Address = walletAddresses[i].GetAddress()
+			reply.Results[i].WatchOnly = walletAddresses[i].IsWatchOnly
 
 			var plainAcc *plain_account.PlainAccount
 			if plainAcc, err = dataStorage.PlainAccs.GetPlainAccount(publicKeyHash); err != nil {
@@ -85,7 +87,7 @@ func (api *APICommon) GetWalletBalances(r *http.Request, args *APIWalletGetBalan
 				if accs, err = dataStorage.AccsCollection.GetMap(assetId); err != nil {
 					return
 				}
-				if acc, err = accs.GetAccount(walletAddresses[i].PublicKey); err != nil {
+				if acc, err = accs.GetAccount(publicKeyHash); err != nil {
 					return
 				}
 
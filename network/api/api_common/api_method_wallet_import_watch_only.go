@@ -0,0 +1,32 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/helpers"
+	"pandora-pay/wallet/wallet_address"
+)
+
+type APIWalletImportWatchOnlyRequest struct {
+	Name      string         `json:"name" msgpack:"name"`
+	Address   string         `json:"address,omitempty" msgpack:"address,omitempty"`
+	PublicKey helpers.Base64 `json:"publicKey,omitempty" msgpack:"publicKey,omitempty"`
+}
+
+type APIWalletImportWatchOnlyReply struct {
+	Address *wallet_address.WalletAddressWatchOnlyExported `json:"address" msgpack:"address"`
+}
+
+func (api *APICommon) GetWalletImportWatchOnly(r *http.Request, args *APIWalletImportWatchOnlyRequest, reply *APIWalletImportWatchOnlyReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	addr, err := api.wallet.ImportWatchOnlyAddress(args.Name, args.Address, args.PublicKey, true)
+	if err != nil {
+		return err
+	}
+
+	reply.Address = addr.ExportWatchOnly()
+	return nil
+}
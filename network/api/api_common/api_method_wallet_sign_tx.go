@@ -0,0 +1,52 @@
+package api_common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/helpers"
+	"pandora-pay/txs_builder/wizard"
+)
+
+type APIWalletSignTxRequest struct {
+	Tx       helpers.Base64 `json:"tx" msgpack:"tx"`
+	Unsigned bool           `json:"unsigned" msgpack:"unsigned"` //tx was serialized without the signatures
+}
+
+type APIWalletSignTxReply struct {
+	Tx      helpers.Base64 `json:"tx" msgpack:"tx"`
+	Hash    helpers.Base64 `json:"hash" msgpack:"hash"`
+	Missing []int          `json:"missing" msgpack:"missing"` //vin that are not signed yet
+}
+
+func (api *APICommon) GetWalletSignTx(r *http.Request, args *APIWalletSignTxRequest, reply *APIWalletSignTxReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	var tx *transaction.Transaction
+	if args.Unsigned {
+		if tx, err = wizard.DeserializeUnsignedTx(args.Tx, func(string) {}); err != nil {
+			return
+		}
+	} else {
+		tx = &transaction.Transaction{}
+		if err = tx.Deserialize(helpers.NewBufferReader(args.Tx)); err != nil {
+			return
+		}
+	}
+
+	if tx, err = api.txsBuilder.SignSimpleTx(tx, false, false, false, context.Background(), func(string) {}); err != nil {
+		return
+	}
+
+	if reply.Missing, err = wizard.GetSimpleTxMissingSignatures(tx); err != nil {
+		return
+	}
+
+	reply.Tx = tx.Bloom.Serialized
+	reply.Hash = tx.Bloom.Hash
+	return
+}
@@ -2,10 +2,12 @@ package api_common
 
 import (
 	"errors"
+	"fmt"
 	"github.com/vmihailenco/msgpack/v5"
 	"pandora-pay/blockchain"
 	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/helpers"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
@@ -31,6 +33,57 @@ func (apiStore *APIStore) loadTxPreview(reader store_db_interface.StoreDBTransac
 	return msgpack.Unmarshal(data, reply)
 }
 
+//txs indexed before the amounts were stored are decoded from the tx
+func (apiStore *APIStore) loadAccountTx(reader store_db_interface.StoreDBTransactionInterface, publicKeyHash []byte, index uint64) (*info.AccountTx, error) {
+
+	indexStr := strconv.FormatUint(index, 10)
+
+	accountTx := &info.AccountTx{}
+	if data := reader.Get("addrTxInfo:" + string(publicKeyHash) + ":" + indexStr); data != nil {
+		if err := msgpack.Unmarshal(data, accountTx); err != nil {
+			return nil, err
+		}
+		return accountTx, nil
+	}
+
+	hash := reader.Get("addrTx:" + string(publicKeyHash) + ":" + indexStr)
+	if hash == nil {
+		return nil, errors.New("Error reading address transaction")
+	}
+
+	data := reader.Get("tx:" + string(hash))
+	if data == nil {
+		return nil, errors.New("Tx not found")
+	}
+
+	tx := &transaction.Transaction{}
+	if err := tx.Deserialize(helpers.NewBufferReader(data)); err != nil {
+		return nil, err
+	}
+	if err := tx.BloomAll(); err != nil {
+		return nil, err
+	}
+
+	txInfo := &info.TxInfo{}
+	if err := apiStore.loadTxInfo(reader, hash, txInfo); err != nil {
+		return nil, err
+	}
+
+	return info.CreateAccountTx(tx, publicKeyHash, txInfo.BlkHeight, txInfo.Timestmap)
+}
+
+func (apiStore *APIStore) loadAssetInfo(reader store_db_interface.StoreDBTransactionInterface, hash []byte) (*info.AssetInfo, error) {
+	data := reader.Get("assetInfo_ByHash:" + string(hash))
+	if data == nil {
+		return nil, errors.New("AssetInfo was not found")
+	}
+	astInfo := &info.AssetInfo{}
+	if err := msgpack.Unmarshal(data, astInfo); err != nil {
+		return nil, err
+	}
+	return astInfo, nil
+}
+
 func (apiStore *APIStore) loadAssetHash(reader store_db_interface.StoreDBTransactionInterface, height uint64) ([]byte, error) {
 	if height < 0 {
 		return nil, errors.New("Height is invalid")
@@ -45,6 +98,21 @@ func (apiStore *APIStore) loadTxHash(reader store_db_interface.StoreDBTransactio
 	return reader.Get("txHash_ByHeight" + strconv.FormatUint(height, 10)), nil
 }
 
+//the txs of the pruned blocks are not stored anymore
+func (apiStore *APIStore) checkBlockPruned(reader store_db_interface.StoreDBTransactionInterface, blockHeight uint64) error {
+	if blockHeight < apiStore.chain.LoadPrunedHeight(reader) {
+		return fmt.Errorf("Block %d was pruned", blockHeight)
+	}
+	return nil
+}
+
+func (apiStore *APIStore) checkTxPruned(reader store_db_interface.StoreDBTransactionInterface, hash []byte) error {
+	if reader.Exists("txBlock:" + string(hash)) {
+		return errors.New("Tx was pruned")
+	}
+	return errors.New("Tx not found")
+}
+
 func (chain *APIStore) loadBlock(reader store_db_interface.StoreDBTransactionInterface, hash []byte) (*block.Block, error) {
 	blockData := reader.Get("block_ByHash" + string(hash))
 	if blockData == nil {
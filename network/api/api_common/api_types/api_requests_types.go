@@ -17,6 +17,7 @@ const (
 	SUBSCRIPTION_ACCOUNT_TRANSACTIONS
 	SUBSCRIPTION_ASSET
 	SUBSCRIPTION_TRANSACTION
+	SUBSCRIPTION_FORGER
 )
 
 type APIReturnType uint8
@@ -52,6 +53,12 @@ func (request *APIAccountBaseRequest) GetPublicKeyHash(required bool) ([]byte, e
 	return publicKeyHash, nil
 }
 
+//the request is answered only if the chain tip is still the pinned one, otherwise a stale view error is returned
+type APIChainPin struct {
+	ChainHash   helpers.Base64 `json:"chainHash,omitempty" msgpack:"chainHash,omitempty"`
+	ChainHeight uint64         `json:"chainHeight,omitempty" msgpack:"chainHeight,omitempty"`
+}
+
 type APISubscriptionRequest struct {
 	Key        helpers.Base64   `json:"key,omitempty" msgpack:"key,omitempty"`
 	Type       SubscriptionType `json:"type,omitempty"  msgpack:"type,omitempty"`
@@ -54,3 +54,12 @@ type APISubscriptionNotificationTxExtra struct {
 	Blockchain *APISubscriptionNotificationTxExtraBlockchain `json:"blockchain,omitempty" msgpack:"blockchain,omitempty"`
 	Mempool    *APISubscriptionNotificationTxExtraMempool    `json:"mempool,omitempty" msgpack:"mempool,omitempty"`
 }
+
+type APISubscriptionNotificationForgerExtra struct {
+	Inserted    bool   `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
+	BlocksCount uint64 `json:"blocksCount" msgpack:"blocksCount"`
+	BlkHeight   uint64 `json:"blkHeight" msgpack:"blkHeight"`
+	Reward      uint64 `json:"reward" msgpack:"reward"`
+	Fees        uint64 `json:"fees" msgpack:"fees"`
+	Commission  uint64 `json:"commission" msgpack:"commission"`
+}
@@ -104,6 +104,7 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 		"tx-hash":                 handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
 		"tx":                      handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
 		"tx/exists":               handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
+		"tx/proof":                handle[api_common.APITxProofRequest, api_common.APITxProofReply](api.apiCommon.GetTxProof),
 		"tx-raw":                  handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
 		"account":                 handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
 		"accounts/count":          handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
@@ -121,7 +122,24 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 		"wallet/decrypt-tx":       handleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
 	}
 
-	api.PostMap = map[string]func(values io.ReadCloser) (interface{}, error){}
+	//write operations and authenticated requests are also accepted via POST to avoid the URL length limits and the credentials in the query string
+	api.PostMap = map[string]func(values io.ReadCloser) (interface{}, error){
+		"mempool/new-tx":            handlePOST[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
+		"mempool/new-txs":           handlePOST[api_common.APIMempoolNewTxsRequest, api_common.APIMempoolNewTxsReply](api.apiCommon.MempoolNewTxs),
+		"batch":                     handlePOST[api_common.APIBatchRequest, api_common.APIBatchReply](api.apiCommon.Batch),
+		"wallet/get-addresses":      handlePOSTAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
+		"wallet/generate-address":   handlePOSTAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
+		"wallet/create-address":     handlePOSTAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
+		"wallet/delete-address":     handlePOSTAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
+		"wallet/get-balances":       handlePOSTAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
+		"wallet/decrypt-tx":         handlePOSTAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"wallet/sign-tx":            handlePOSTAuthenticated[api_common.APIWalletSignTxRequest, api_common.APIWalletSignTxReply](api.apiCommon.GetWalletSignTx),
+		"wallet/create-unsigned-tx": handlePOSTAuthenticated[api_common.APIWalletCreateUnsignedTxRequest, api_common.APIWalletCreateUnsignedTxReply](api.apiCommon.WalletCreateUnsignedTx),
+		"wallet/import-watch-only":  handlePOSTAuthenticated[api_common.APIWalletImportWatchOnlyRequest, api_common.APIWalletImportWatchOnlyReply](api.apiCommon.GetWalletImportWatchOnly),
+		"wallet/export-watch-only":  handlePOSTAuthenticated[struct{}, api_common.APIWalletExportWatchOnlyReply](api.apiCommon.GetWalletExportWatchOnly),
+	}
+
+	api.GetMap["wallet/export-watch-only"] = handleAuthenticated[struct{}, api_common.APIWalletExportWatchOnlyReply](api.apiCommon.GetWalletExportWatchOnly)
 
 	if config.SEED_WALLET_NODES_INFO {
 		api.GetMap["asset-info"] = handle[api_common.APIAssetInfoRequest, info.AssetInfo](api.apiCommon.GetAssetInfo)
@@ -129,6 +147,11 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 		api.GetMap["tx-info"] = handle[api_common.APITransactionInfoRequest, info.TxInfo](api.apiCommon.GetTxInfo)
 		api.GetMap["tx-preview"] = handle[api_common.APITransactionPreviewRequest, api_common.APITransactionPreviewReply](api.apiCommon.GetTxPreview)
 		api.GetMap["account/txs"] = handle[api_common.APIAccountTxsRequest, api_common.APIAccountTxsReply](api.apiCommon.GetAccountTxs)
+		api.GetMap["assets/list"] = handle[api_common.APIAssetsListRequest, api_common.APIAssetsListReply](api.apiCommon.GetAssetsList)
+		api.GetMap["assets/search"] = handle[api_common.APIAssetsSearchRequest, api_common.APIAssetsSearchReply](api.apiCommon.GetAssetsSearch)
+		api.GetMap["asset/holders"] = handle[api_common.APIAssetHoldersRequest, api_common.APIAssetHoldersReply](api.apiCommon.GetAssetHolders)
+		api.GetMap["forger/stats"] = handle[api_common.APIForgerStatsRequest, api_common.APIForgerStatsReply](api.apiCommon.GetForgerStats)
+		api.GetMap["forger/blocks"] = handle[api_common.APIForgerBlocksRequest, api_common.APIForgerBlocksReply](api.apiCommon.GetForgerBlocks)
 		api.GetMap["account/mempool"] = handle[api_common.APIAccountMempoolRequest, api_common.APIAccountMempoolReply](api.apiCommon.GetAccountMempool)
 		api.GetMap["account/mempool-nonce"] = handle[api_common.APIAccountMempoolNonceRequest, api_common.APIAccountMempoolNonceReply](api.apiCommon.GetAccountMempoolNonce)
 	}
@@ -137,12 +160,14 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 		api.GetMap["faucet/info"] = handle[struct{}, api_faucet.APIFaucetInfo](api.apiCommon.Faucet.GetFaucetInfo)
 		if config.FAUCET_TESTNET_ENABLED {
 			api.GetMap["faucet/coins"] = handle[api_faucet.APIFaucetCoinsRequest, api_faucet.APIFaucetCoinsReply](api.apiCommon.Faucet.GetFaucetCoins)
+			api.PostMap["faucet/coins"] = handlePOST[api_faucet.APIFaucetCoinsRequest, api_faucet.APIFaucetCoinsReply](api.apiCommon.Faucet.GetFaucetCoins)
 		}
 	}
 
 	if api.apiCommon.DelegatorNode != nil {
 		api.GetMap["delegator-node/info"] = handle[struct{}, api_delegator_node.ApiDelegatorNodeInfoReply](api.apiCommon.DelegatorNode.GetDelegatorNodeInfo)
 		api.GetMap["delegator-node/notify"] = handleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify)
+		api.PostMap["delegator-node/notify"] = handlePOSTAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify)
 	}
 
 	return &api
@@ -84,6 +84,7 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		"tx-hash":                 handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
 		"tx":                      handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
 		"tx/exists":               handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
+		"tx/proof":                handle[api_common.APITxProofRequest, api_common.APITxProofReply](api.apiCommon.GetTxProof),
 		"tx-raw":                  handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
 		"account":                 handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
 		"accounts/count":          handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
@@ -92,6 +93,8 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		"mempool":                 handle[api_common.APIMempoolRequest, api_common.APIMempoolReply](api.apiCommon.GetMempool),
 		"mempool/tx-exists":       handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
 		"mempool/new-tx":          handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
+		"mempool/new-txs":         handle[api_common.APIMempoolNewTxsRequest, api_common.APIMempoolNewTxsReply](api.apiCommon.MempoolNewTxs),
+		"batch":                   handle[api_common.APIBatchRequest, api_common.APIBatchReply](api.apiCommon.Batch),
 		"network/nodes":           handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
 		"wallet/get-addresses":    handleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
 		"wallet/generate-address": handleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
@@ -99,8 +102,12 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		"wallet/delete-address":   handleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
 		"wallet/get-balances":     handleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
 		"wallet/decrypt-tx":       handleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"wallet/sign-tx":          handleAuthenticated[api_common.APIWalletSignTxRequest, api_common.APIWalletSignTxReply](api.apiCommon.GetWalletSignTx),
 		//below are ONLY websockets API
 		"block-miss-txs":    handle[consensus.APIBlockCompleteMissingTxsRequest, consensus.APIBlockCompleteMissingTxsReply](api.Consensus.GetBlockCompleteMissingTxs),
+		"snapshot":          handle[consensus.APISnapshotRequest, consensus.APISnapshotReply](api.Consensus.GetSnapshot),
+		"snapshot/chunk":    handle[consensus.APISnapshotChunkRequest, consensus.APISnapshotChunkReply](api.Consensus.GetSnapshotChunk),
+		"snapshot/headers":  handle[consensus.APISnapshotHeadersRequest, consensus.APISnapshotHeadersReply](api.Consensus.GetSnapshotHeaders),
 		"handshake":         api.handshake,
 		"mempool/new-tx-id": api.apiCommon.MempoolNewTxId,
 		"get-chain":         api.Consensus.GetChain,
@@ -111,12 +118,21 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		"unsub":             api.unsubscribe,
 	}
 
+	api.GetMap["wallet/create-unsigned-tx"] = handleAuthenticated[api_common.APIWalletCreateUnsignedTxRequest, api_common.APIWalletCreateUnsignedTxReply](api.apiCommon.WalletCreateUnsignedTx)
+	api.GetMap["wallet/import-watch-only"] = handleAuthenticated[api_common.APIWalletImportWatchOnlyRequest, api_common.APIWalletImportWatchOnlyReply](api.apiCommon.GetWalletImportWatchOnly)
+	api.GetMap["wallet/export-watch-only"] = handleAuthenticated[struct{}, api_common.APIWalletExportWatchOnlyReply](api.apiCommon.GetWalletExportWatchOnly)
+
 	if config.SEED_WALLET_NODES_INFO {
 		api.GetMap["asset-info"] = handle[api_common.APIAssetInfoRequest, info.AssetInfo](api.apiCommon.GetAssetInfo)
 		api.GetMap["block-info"] = handle[api_common.APIBlockInfoRequest, info.BlockInfo](api.apiCommon.GetBlockInfo)
 		api.GetMap["tx-info"] = handle[api_common.APITransactionInfoRequest, info.TxInfo](api.apiCommon.GetTxInfo)
 		api.GetMap["tx-preview"] = handle[api_common.APITransactionPreviewRequest, api_common.APITransactionPreviewReply](api.apiCommon.GetTxPreview)
 		api.GetMap["account/txs"] = handle[api_common.APIAccountTxsRequest, api_common.APIAccountTxsReply](api.apiCommon.GetAccountTxs)
+		api.GetMap["assets/list"] = handle[api_common.APIAssetsListRequest, api_common.APIAssetsListReply](api.apiCommon.GetAssetsList)
+		api.GetMap["assets/search"] = handle[api_common.APIAssetsSearchRequest, api_common.APIAssetsSearchReply](api.apiCommon.GetAssetsSearch)
+		api.GetMap["asset/holders"] = handle[api_common.APIAssetHoldersRequest, api_common.APIAssetHoldersReply](api.apiCommon.GetAssetHolders)
+		api.GetMap["forger/stats"] = handle[api_common.APIForgerStatsRequest, api_common.APIForgerStatsReply](api.apiCommon.GetForgerStats)
+		api.GetMap["forger/blocks"] = handle[api_common.APIForgerBlocksRequest, api_common.APIForgerBlocksReply](api.apiCommon.GetForgerBlocks)
 		api.GetMap["account/mempool"] = handle[api_common.APIAccountMempoolRequest, api_common.APIAccountMempoolReply](api.apiCommon.GetAccountMempool)
 		api.GetMap["account/mempool-nonce"] = handle[api_common.APIAccountMempoolNonceRequest, api_common.APIAccountMempoolNonceReply](api.apiCommon.GetAccountMempoolNonce)
 	}
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"errors"
+	"fmt"
 	"github.com/vmihailenco/msgpack/v5"
 	"net/http"
 	"pandora-pay/helpers"
@@ -32,6 +33,10 @@ func (api *Consensus) GetBlockCompleteMissingTxs(r *http.Request, args *APIBlock
 			return
 		}
 
+		if height < api.chain.LoadPrunedHeight(reader) {
+			return fmt.Errorf("Block %d was pruned", height)
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(height, 10))
 		if data == nil {
 			return errors.New("Block not found")
@@ -0,0 +1,55 @@
+package consensus
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+)
+
+type APISnapshotRequest struct {
+	Height uint64 `json:"height,omitempty" msgpack:"height,omitempty"`
+}
+
+type APISnapshotReply struct {
+	Hash   helpers.Base64 `json:"hash" msgpack:"hash"`
+	Chunks int            `json:"chunks" msgpack:"chunks"`
+}
+
+type APISnapshotChunkRequest struct {
+	Height uint64         `json:"height,omitempty" msgpack:"height,omitempty"`
+	Hash   helpers.Base64 `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	Index  int            `json:"index,omitempty" msgpack:"index,omitempty"`
+}
+
+type APISnapshotChunkReply struct {
+	Data []byte `json:"data,omitempty" msgpack:"data,omitempty"`
+}
+
+type APISnapshotHeadersRequest struct {
+	Start uint64 `json:"start,omitempty" msgpack:"start,omitempty"`
+	Count int    `json:"count,omitempty" msgpack:"count,omitempty"`
+}
+
+type APISnapshotHeadersReply struct {
+	Headers [][]byte `json:"headers,omitempty" msgpack:"headers,omitempty"`
+}
+
+//the snapshots are created only when the blocks are included, so the requests can't trigger their creation
+func (api *Consensus) GetSnapshot(r *http.Request, args *APISnapshotRequest, reply *APISnapshotReply) (err error) {
+	reply.Hash, reply.Chunks, err = api.chain.OpenLoadSnapshotInfo(args.Height)
+	return
+}
+
+func (api *Consensus) GetSnapshotChunk(r *http.Request, args *APISnapshotChunkRequest, reply *APISnapshotChunkReply) (err error) {
+	reply.Data, err = api.chain.OpenLoadSnapshotChunk(args.Height, args.Hash, args.Index)
+	return
+}
+
+func (api *Consensus) GetSnapshotHeaders(r *http.Request, args *APISnapshotHeadersRequest, reply *APISnapshotHeadersReply) (err error) {
+	if args.Count <= 0 || args.Count > config.SNAPSHOT_HEADERS_MAX_PER_REQUEST {
+		return errors.New("Count is invalid")
+	}
+	reply.Headers, err = api.chain.OpenLoadBlocksHeaders(args.Start, args.Count)
+	return
+}
@@ -29,7 +29,7 @@ type ConsensusProcessForksThread struct {
 }
 
 func (thread *ConsensusProcessForksThread) downloadBlockHash(conn *connection.AdvancedConnection, fork *Fork, height uint64) ([]byte, error) {
-	answer, err := connection.SendJSONAwaitAnswer[api_common.APIBlockHashReply](conn, []byte("block-hash"), &api_common.APIBlockHashRequest{height}, nil, 0)
+	answer, err := connection.SendJSONAwaitAnswer[api_common.APIBlockHashReply](conn, []byte("block-hash"), &api_common.APIBlockHashRequest{height, api_types.APIChainPin{}}, nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -43,11 +43,15 @@ func (thread *ConsensusProcessForksThread) downloadBlockHash(conn *connection.Ad
 
 func (thread *ConsensusProcessForksThread) downloadBlockComplete(conn *connection.AdvancedConnection, fork *Fork, height uint64) (*block_complete.BlockComplete, error) {
 
-	blkWithTx, err := connection.SendJSONAwaitAnswer[api_common.APIBlockReply](conn, []byte("block"), &api_common.APIBlockRequest{height, nil, api_types.RETURN_SERIALIZED}, nil, 0)
+	blkWithTx, err := connection.SendJSONAwaitAnswer[api_common.APIBlockReply](conn, []byte("block"), &api_common.APIBlockRequest{height, nil, api_types.RETURN_SERIALIZED, api_types.APIChainPin{}}, nil, 0)
 	if err != nil {
 		return nil, err
 	}
 
+	if blkWithTx.Pruned {
+		return nil, errors.New("Block was pruned")
+	}
+
 	blkWithTx.Block = block.CreateEmptyBlock()
 	if err = blkWithTx.Block.Deserialize(helpers.NewBufferReader(blkWithTx.BlockSerialized)); err != nil {
 		return nil, err
@@ -242,6 +246,12 @@ func (thread *ConsensusProcessForksThread) execute() {
 
 			if config.CONSENSUS == config.CONSENSUS_TYPE_FULL {
 
+				if config.SNAPSHOT_SYNC && thread.chain.GetChainData().Height == 0 && fork.End > config.SNAPSHOT_SYNC_CHECKPOINT_HEIGHT+1 {
+					if err := thread.downloadSnapshot(fork); err != nil {
+						gui.GUI.Error("Snapshot sync failed", err)
+					}
+				}
+
 				if thread.downloadFork(fork) {
 
 					globals.MainEvents.BroadcastEvent("consensus/update", fork)
@@ -0,0 +1,69 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/network/websocks/connection"
+	"strconv"
+)
+
+//the state is downloaded at the checkpoint block. The headers from genesis to the checkpoint are verified and the remaining blocks are included afterwards
+func (thread *ConsensusProcessForksThread) downloadSnapshot(fork *Fork) error {
+
+	fork.Lock()
+	defer fork.Unlock()
+
+	conn := fork.getRandomConn()
+	if conn == nil {
+		return errors.New("Fork has no connections")
+	}
+
+	answer, err := connection.SendJSONAwaitAnswer[APISnapshotReply](conn, []byte("snapshot"), &APISnapshotRequest{config.SNAPSHOT_SYNC_CHECKPOINT_HEIGHT}, nil, 0)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(answer.Hash, config.SNAPSHOT_SYNC_CHECKPOINT_HASH) {
+		return errors.New("Snapshot checkpoint hash is not matching")
+	}
+	if answer.Chunks <= 0 || answer.Chunks > config.SNAPSHOT_CHUNKS_MAX {
+		return errors.New("Snapshot chunks count is invalid")
+	}
+
+	headers, err := thread.chain.NewBlockchainSnapshotHeaders(config.SNAPSHOT_SYNC_CHECKPOINT_HEIGHT, config.SNAPSHOT_SYNC_CHECKPOINT_HASH)
+	if err != nil {
+		return err
+	}
+
+	gui.GUI.Info("Downloading snapshot headers " + strconv.FormatUint(config.SNAPSHOT_SYNC_CHECKPOINT_HEIGHT, 10))
+
+	for !headers.Done() {
+		reply, err := connection.SendJSONAwaitAnswer[APISnapshotHeadersReply](conn, []byte("snapshot/headers"), &APISnapshotHeadersRequest{headers.Next(), config.SNAPSHOT_HEADERS_MAX_PER_REQUEST}, nil, 0)
+		if err != nil {
+			return err
+		}
+		if len(reply.Headers) == 0 || len(reply.Headers) > config.SNAPSHOT_HEADERS_MAX_PER_REQUEST {
+			return errors.New("Snapshot headers are invalid")
+		}
+		if err = headers.AddHeaders(reply.Headers); err != nil {
+			return err
+		}
+	}
+
+	gui.GUI.Info("Downloading snapshot " + strconv.FormatUint(config.SNAPSHOT_SYNC_CHECKPOINT_HEIGHT, 10))
+
+	chunks := make([][]byte, answer.Chunks)
+	for i := range chunks {
+		chunk, err := connection.SendJSONAwaitAnswer[APISnapshotChunkReply](conn, []byte("snapshot/chunk"), &APISnapshotChunkRequest{config.SNAPSHOT_SYNC_CHECKPOINT_HEIGHT, answer.Hash, i}, nil, 0)
+		if err != nil {
+			return err
+		}
+		if len(chunk.Data) == 0 || len(chunk.Data) > config.SNAPSHOT_CHUNK_MAX_SIZE {
+			return errors.New("Snapshot chunk size is invalid")
+		}
+		chunks[i] = chunk.Data
+	}
+
+	return thread.chain.ImportSnapshot(headers, chunks)
+}
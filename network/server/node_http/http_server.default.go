@@ -98,6 +98,15 @@ func (server *HttpServer) post(w http.ResponseWriter, req *http.Request) {
 	w.Write(final)
 }
 
+//the same route can be used with GET and POST
+func (server *HttpServer) handle(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodPost {
+		server.post(w, req)
+	} else {
+		server.get(w, req)
+	}
+}
+
 func (server *HttpServer) GetHttpHandler() *http.Handler {
 
 	mux := http.NewServeMux()
@@ -110,12 +119,14 @@ func (server *HttpServer) GetHttpHandler() *http.Handler {
 	}
 
 	for key, callback := range server.Api.GetMap {
-		mux.HandleFunc("/"+key, server.get)
+		mux.HandleFunc("/"+key, server.handle)
 		server.GetMap["/"+key] = callback
 	}
 
 	for key, callback := range server.Api.PostMap {
-		mux.HandleFunc("/"+key, server.post)
+		if server.GetMap["/"+key] == nil {
+			mux.HandleFunc("/"+key, server.handle)
+		}
 		server.PostMap["/"+key] = callback
 	}
 
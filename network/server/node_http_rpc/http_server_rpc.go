@@ -19,7 +19,7 @@ func InitializeRPC(apiCommon *api_common.APICommon) (err error) {
 		return
 	}
 
-	http.Handle("/rpc/api/v1", s)
+	http.Handle("/rpc/api/v1", handleBatch(apiCommon, s))
 
 	return
 }
@@ -0,0 +1,120 @@
+package node_http_rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"pandora-pay/network/api/api_common"
+)
+
+type rpcBatchRequest struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	Id      json.RawMessage `json:"id"`
+}
+
+type rpcBatchError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcBatchReply struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcBatchError  `json:"error,omitempty"`
+	Id      json.RawMessage `json:"id"`
+}
+
+//the params can be sent as an object or as an array with a single object
+func rpcBatchParams(params json.RawMessage) (json.RawMessage, error) {
+	params = bytes.TrimSpace(params)
+	if len(params) == 0 || params[0] != '[' {
+		return params, nil
+	}
+
+	list := []json.RawMessage{}
+	if err := json.Unmarshal(params, &list); err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func writeBatchError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&rpcBatchReply{"2.0", nil, &rpcBatchError{code, message}, json.RawMessage("null")})
+}
+
+//json rpc 2.0 batches are processed by the api using a single view of the store. Single requests are forwarded to the rpc server
+func handleBatch(apiCommon *api_common.APICommon, server http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodPost {
+			server.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeBatchError(w, -32700, err.Error())
+			return
+		}
+
+		trimmed := bytes.TrimSpace(body)
+		if len(trimmed) == 0 || trimmed[0] != '[' {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			server.ServeHTTP(w, r)
+			return
+		}
+
+		requests := []*rpcBatchRequest{}
+		if err = json.Unmarshal(trimmed, &requests); err != nil {
+			writeBatchError(w, -32700, err.Error())
+			return
+		}
+
+		items := make([]*api_common.APIBatchRequestItem, len(requests))
+		replies := make([]*rpcBatchReply, len(requests))
+		for i, request := range requests {
+			items[i] = &api_common.APIBatchRequestItem{}
+			if request == nil {
+				replies[i] = &rpcBatchReply{"2.0", nil, &rpcBatchError{-32600, "Request is invalid"}, json.RawMessage("null")}
+				continue
+			}
+			replies[i] = &rpcBatchReply{"2.0", nil, nil, request.Id}
+			if request.Method == "" {
+				replies[i].Error = &rpcBatchError{-32600, "Method is missing"}
+				continue
+			}
+			if items[i].Params, err = rpcBatchParams(request.Params); err != nil {
+				replies[i].Error = &rpcBatchError{-32602, err.Error()}
+				continue
+			}
+			items[i].Method = upMethodName(request.Method)
+		}
+
+		results, err := apiCommon.BatchRPC(items)
+		if err != nil {
+			writeBatchError(w, -32600, err.Error())
+			return
+		}
+
+		for i, result := range results {
+			if replies[i].Error != nil {
+				continue
+			}
+			if result.Error != "" {
+				replies[i].Error = &rpcBatchError{-32000, result.Error}
+			} else {
+				replies[i].Result = result.Result
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(replies)
+	})
+}
@@ -52,26 +52,31 @@ type UpCodecRequest struct {
 func (c *UpCodecRequest) Method() (string, error) {
 	m, err := c.CodecRequest.Method()
 	if len(m) > 1 && err == nil {
+		return "api." + upMethodName(m), err
+	}
+	return m, err
+}
 
-		final := make([]byte, len(m))
-		c := 0
-		for i := 0; i < len(m); i++ {
-			if m[i] == '/' || m[i] == '-' {
-				final[c] = m[i+1] - 32
-				c += 1
-				i += 1
-				continue
-			} else if i == 0 {
-				final[c] = m[0] - 32
-				c += 1
-			} else {
-				final[c] = m[i]
-				c += 1
-			}
-		}
+// upMethodName converts a method like "getBlock" or "tx/proof" into the
+// name of the APICommon method, for instance "GetBlock" or "TxProof"
+func upMethodName(m string) string {
 
-		upMethod := "api." + string(final[:c])
-		return upMethod, err
+	final := make([]byte, len(m))
+	c := 0
+	for i := 0; i < len(m); i++ {
+		if m[i] == '/' || m[i] == '-' {
+			final[c] = m[i+1] - 32
+			c += 1
+			i += 1
+			continue
+		} else if i == 0 {
+			final[c] = m[0] - 32
+			c += 1
+		} else {
+			final[c] = m[i]
+			c += 1
+		}
 	}
-	return m, err
+
+	return string(final[:c])
 }
@@ -23,7 +23,7 @@ type Subscriptions struct {
 func checkSubscriptionLength(key []byte, subscriptionType api_types.SubscriptionType) error {
 	var length int
 	switch subscriptionType {
-	case api_types.SUBSCRIPTION_PLAIN_ACCOUNT, api_types.SUBSCRIPTION_ACCOUNT, api_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS:
+	case api_types.SUBSCRIPTION_PLAIN_ACCOUNT, api_types.SUBSCRIPTION_ACCOUNT, api_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS, api_types.SUBSCRIPTION_FORGER:
 		length = cryptography.PublicKeyHashSize
 	case api_types.SUBSCRIPTION_ASSET:
 		length = config_coins.ASSET_LENGTH
@@ -24,6 +24,7 @@ type WebsocketSubscriptions struct {
 	accountsTransactionsSubscriptions map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	assetsSubscriptions               map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	transactionsSubscriptions         map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	forgersSubscriptions              map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 }
 
 func newWebsocketSubscriptions(websockets *Websockets, chain *blockchain.Blockchain, mempool *mempool.Mempool) (subs *WebsocketSubscriptions) {
@@ -36,6 +37,7 @@ func newWebsocketSubscriptions(websockets *Websockets, chain *blockchain.Blockch
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 	}
 
 	if config.SEED_WALLET_NODES_INFO {
@@ -104,6 +106,8 @@ func (this *WebsocketSubscriptions) getSubsMap(subscriptionType api_types.Subscr
 		subsMap = this.assetsSubscriptions
 	case api_types.SUBSCRIPTION_TRANSACTION:
 		subsMap = this.transactionsSubscriptions
+	case api_types.SUBSCRIPTION_FORGER:
+		subsMap = this.forgersSubscriptions
 	}
 	return
 }
@@ -141,6 +145,9 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 	updateTransactionsCn := this.chain.UpdateSocketsSubscriptionsTransactions.AddListener()
 	defer this.chain.UpdateSocketsSubscriptionsTransactions.RemoveChannel(updateTransactionsCn)
 
+	updateForgersCn := this.chain.UpdateSocketsSubscriptionsForgers.AddListener()
+	defer this.chain.UpdateSocketsSubscriptionsForgers.RemoveChannel(updateForgersCn)
+
 	updateMempoolTransactionsCn := this.mempool.Txs.UpdateMempoolTransactions.AddListener()
 	defer this.mempool.Txs.UpdateMempoolTransactions.RemoveChannel(updateMempoolTransactionsCn)
 
@@ -234,6 +241,19 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 				}
 			}
 
+		case forgersUpdates, ok := <-updateForgersCn:
+			if !ok {
+				return
+			}
+
+			for _, v := range forgersUpdates {
+				if list := this.forgersSubscriptions[string(v.Forger)]; list != nil {
+					this.send(api_types.SUBSCRIPTION_FORGER, []byte("sub/notify"), v.Forger, list, nil, v.BlockHash, &api_types.APISubscriptionNotificationForgerExtra{
+						v.Inserted, v.BlocksCount, v.BlockHeight, v.Reward, v.Fees, v.Commission,
+					})
+				}
+			}
+
 		case txUpdate, ok := <-updateMempoolTransactionsCn:
 			if !ok {
 				return
@@ -262,6 +282,7 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			this.removeConnection(conn, api_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS)
 			this.removeConnection(conn, api_types.SUBSCRIPTION_ASSET)
 			this.removeConnection(conn, api_types.SUBSCRIPTION_TRANSACTION)
+			this.removeConnection(conn, api_types.SUBSCRIPTION_FORGER)
 
 		}
 
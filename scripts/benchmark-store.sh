@@ -0,0 +1,47 @@
+if [ $# -eq 0 ]; then
+  echo "argument missing"
+  echo "path of the file exported with --chain-export"
+  echo "stores=\"bolt,bunt,leveldb\" to select the store types compared"
+  echo "any other argument is passed to the node, like --network=\"devnet\" or --seed-wallet-nodes-info=\"true\""
+  exit 1
+fi
+
+file=""
+stores="bolt,bunt,leveldb"
+extraArgs=""
+
+for arg in "$@"; do
+  if [[ $arg == "stores="* ]]; then
+    stores="${arg#stores=}"
+  elif [[ $arg == "--"* ]]; then
+    extraArgs+=" $arg "
+  else
+    file="$(realpath "$arg")"
+  fi
+done
+
+if [ ! -f "$file" ]; then
+  echo "chain file $file not found"
+  exit 1
+fi
+
+go build -o ./bin/pandora-benchmark main.go || exit 1
+
+IFS=',' read -ra types <<<"$stores"
+
+results=""
+for type in "${types[@]}"; do
+
+  echo "replaying on $type"
+  rm -r ./_build/webd2/benchmark-$type"_0"/*/store/blockchain_store.* 2>/dev/null
+
+  start=$(date +%s.%N)
+  ./bin/pandora-benchmark --instance="benchmark-$type" --store-chain-type="$type" --chain-import="$file" --skip-init-sync --exit $extraArgs >/dev/null
+  end=$(date +%s.%N)
+
+  size=$(du -sh ./_build/webd2/benchmark-$type"_0"/*/store/blockchain_store.* | cut -f1)
+  results+="$type $(echo "$end - $start" | bc) seconds, store size $size\n"
+
+done
+
+echo -e "$results"
@@ -99,7 +99,7 @@ func StartMainNow() (err error) {
 	app.TxsBuilder = txs_builder.TxsBuilderInit(app.Wallet, app.Mempool, app.TxsValidator)
 	globals.MainEvents.BroadcastEvent("main", "transactions builder initialized")
 
-	app.Forging.InitializeForging(app.Chain.NextBlockCreatedCn, app.Chain.UpdateNewChainUpdate, app.Chain.ForgingSolutionCn)
+	app.Forging.InitializeForging(app.Chain.NextBlockCreatedCn, app.Chain.UpdateNewChainUpdate, app.Chain.ForgingSolutionCn, app.Chain.ComputeBlockStateRoot)
 
 	if config_forging.FORGING_ENABLED {
 		app.Forging.StartForging()
@@ -110,6 +110,10 @@ func StartMainNow() (err error) {
 
 	app.Chain.InitForging()
 
+	if err = app.Chain.ProcessChainArguments(); err != nil {
+		return
+	}
+
 	if globals.Arguments["--exit"] == true {
 		os.Exit(1)
 		return
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math/rand"
 	"pandora-pay/helpers"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -23,6 +24,9 @@ type HashMap struct {
 	DeletedEvent   func([]byte) error
 	StoredEvent    func([]byte, *CommittedMapElement) error
 	Indexable      bool
+	StateTree      *state_tree.StateTree //commitment of the stored data
+	StateTreeKey   []byte                //prefix of the keys in the state tree, used when the tree is shared
+	stateChanges   map[string]bool
 }
 
 func (hashMap *HashMap) deserialize(key, data []byte, index uint64) (HashMapElementSerializableInterface, error) {
@@ -89,6 +93,80 @@ func (hashMap *HashMap) GetByIndex(index uint64) (data helpers.SerializableInter
 	return hashMap.Get(string(key))
 }
 
+//the serialized entries are read in chunks, so the store can be used by the callback
+func (hashMap *HashMap) iterateSerialized(start string, reverse bool, callback func(key string, data []byte) (bool, error)) (err error) {
+
+	prefix := hashMap.name + ":map:"
+
+	var seek string
+	if start != "" {
+		seek = prefix + start
+	}
+
+	type entry struct {
+		key  string
+		data []byte
+	}
+
+	var last string
+	for {
+
+		entries := make([]*entry, 0, 100)
+		if err = hashMap.Tx.Iterate(prefix, seek, reverse, func(key string, value []byte) bool {
+			if key == last {
+				return true
+			}
+			entries = append(entries, &entry{key[len(prefix):], value})
+			return len(entries) < cap(entries)
+		}); err != nil {
+			return
+		}
+
+		for _, it := range entries {
+			var next bool
+			if next, err = callback(it.key, it.data); err != nil || !next {
+				return
+			}
+		}
+
+		if len(entries) < cap(entries) {
+			return
+		}
+		last = prefix + entries[len(entries)-1].key
+		seek = last
+	}
+}
+
+//support only for commited data. The elements are iterated in the order of their keys starting from the start key (included)
+func (hashMap *HashMap) Iterate(start string, reverse bool, callback func(key string, element HashMapElementSerializableInterface) (bool, error)) (err error) {
+
+	if hashMap.changed {
+		return errors.New("Iterate is supported only when is committed")
+	}
+
+	return hashMap.iterateSerialized(start, reverse, func(key string, data []byte) (bool, error) {
+
+		var index uint64
+		if hashMap.Indexable {
+			indexData := hashMap.Tx.Get(hashMap.name + ":listKeys:" + key)
+			if indexData == nil {
+				return false, errors.New("Key not found")
+			}
+			var err error
+			if index, err = strconv.ParseUint(string(indexData), 10, 64); err != nil {
+				return false, err
+			}
+		}
+
+		element, err := hashMap.deserialize([]byte(key), data, index)
+		if err != nil {
+			return false, err
+		}
+
+		return callback(key, element)
+	})
+}
+
 //support only for commited data
 func (hashMap *HashMap) GetRandom() (data helpers.SerializableInterface, err error) {
 	if !hashMap.Indexable {
@@ -144,6 +222,36 @@ func (hashMap *HashMap) Get(key string) (out HashMapElementSerializableInterface
 	return
 }
 
+//returns the element serialized as it is stored. It doesn't include the changes that are not committed
+func (hashMap *HashMap) GetSerialized(key string) ([]byte, error) {
+
+	if hashMap.keyLength != 0 && len(key) != hashMap.keyLength {
+		return nil, errors.New("key length is invalid")
+	}
+
+	if exists := hashMap.Committed[key]; exists != nil {
+		return helpers.CloneBytes(exists.serialized), nil
+	}
+
+	//clone required because data could be altered afterwards
+	return helpers.CloneBytes(hashMap.Tx.Get(hashMap.name + ":map:" + key)), nil
+}
+
+//stores an element received serialized
+func (hashMap *HashMap) UpdateSerialized(key string, data []byte) error {
+
+	if hashMap.keyLength != 0 && len(key) != hashMap.keyLength {
+		return errors.New("key length is invalid")
+	}
+
+	obj, err := hashMap.deserialize([]byte(key), data, 0)
+	if err != nil {
+		return err
+	}
+
+	return hashMap.Update(key, obj)
+}
+
 func (hashMap *HashMap) Exists(key string) (bool, error) {
 
 	if hashMap.keyLength != 0 && len(key) != hashMap.keyLength {
@@ -347,6 +455,7 @@ func (hashMap *HashMap) CommitChanges() (err error) {
 			committed.Element = nil
 			committed.size = 0
 			committed.serialized = nil
+			hashMap.stateChanges[k] = true
 
 			if hashMap.Tx.Exists(hashMap.name + ":exists:" + k) {
 
@@ -423,6 +532,7 @@ func (hashMap *HashMap) CommitChanges() (err error) {
 
 			committed.Status = "view"
 			committed.Stored = "update"
+			hashMap.stateChanges[k] = true
 
 			v.indexProcess = false
 		}
@@ -443,11 +553,62 @@ func (hashMap *HashMap) CommitChanges() (err error) {
 
 	hashMap.changed = false
 
+	if hashMap.Tx.IsWritable() {
+		return hashMap.UpdateStateTree()
+	}
+
+	return
+}
+
+//all the committed elements are written to the state tree, used by the stores created before the state trees
+func (hashMap *HashMap) BuildStateTree() (err error) {
+
+	if hashMap.StateTree == nil {
+		return
+	}
+	if hashMap.changed {
+		return errors.New("BuildStateTree is supported only when is committed")
+	}
+
+	return hashMap.iterateSerialized("", false, func(key string, data []byte) (bool, error) {
+		if err := hashMap.StateTree.Update(append(helpers.CloneBytes(hashMap.StateTreeKey), key...), data); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+//the committed changes are applied to the state tree
+func (hashMap *HashMap) UpdateStateTree() (err error) {
+
+	if hashMap.StateTree == nil {
+		return
+	}
+
+	for k := range hashMap.stateChanges {
+
+		committed := hashMap.Committed[k]
+		key := append(helpers.CloneBytes(hashMap.StateTreeKey), k...)
+
+		if committed == nil || committed.Element == nil {
+			err = hashMap.StateTree.Delete(key)
+		} else {
+			err = hashMap.StateTree.Update(key, committed.serialized)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	hashMap.stateChanges = make(map[string]bool)
 	return
 }
 
 func (hashMap *HashMap) SetTx(dbTx store_db_interface.StoreDBTransactionInterface) {
 	hashMap.Tx = dbTx
+	if hashMap.StateTree != nil {
+		hashMap.StateTree.SetTx(dbTx)
+	}
 }
 
 func (hashMap *HashMap) Rollback() {
@@ -459,6 +620,7 @@ func (hashMap *HashMap) Rollback() {
 
 func (hashMap *HashMap) Reset() {
 	hashMap.Committed = make(map[string]*CommittedMapElement)
+	hashMap.stateChanges = make(map[string]bool)
 	hashMap.Changes = make(map[string]*ChangesMapElement)
 	hashMap.changesSize = make(map[string]*ChangesMapElement)
 	hashMap.changed = false
@@ -471,14 +633,15 @@ func CreateNewHashMap(tx store_db_interface.StoreDBTransactionInterface, name st
 	}
 
 	hashMap = &HashMap{
-		name:        name,
-		Committed:   make(map[string]*CommittedMapElement),
-		Changes:     make(map[string]*ChangesMapElement),
-		changesSize: make(map[string]*ChangesMapElement),
-		Tx:          tx,
-		Count:       0,
-		keyLength:   keyLength,
-		Indexable:   indexable,
+		name:         name,
+		Committed:    make(map[string]*CommittedMapElement),
+		Changes:      make(map[string]*ChangesMapElement),
+		changesSize:  make(map[string]*ChangesMapElement),
+		stateChanges: make(map[string]bool),
+		Tx:           tx,
+		Count:        0,
+		keyLength:    keyLength,
+		Indexable:    indexable,
 	}
 
 	//safe to Get because data will be converted into an integer
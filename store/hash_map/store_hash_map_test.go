@@ -0,0 +1,190 @@
+package hash_map
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+type testElement struct {
+	Key   []byte
+	Value uint64
+	index uint64
+}
+
+func (self *testElement) IsDeletable() bool {
+	return false
+}
+
+func (self *testElement) SetKey(key []byte) {
+	self.Key = key
+}
+
+func (self *testElement) SetIndex(index uint64) {
+	self.index = index
+}
+
+func (self *testElement) GetIndex() uint64 {
+	return self.index
+}
+
+func (self *testElement) Validate() error {
+	return nil
+}
+
+func (self *testElement) Serialize(w *helpers.BufferWriter) {
+	w.WriteUvarint(self.Value)
+}
+
+func (self *testElement) Deserialize(r *helpers.BufferReader) (err error) {
+	self.Value, err = r.ReadUvarint()
+	return
+}
+
+func createTestHashMap(dbTx store_db_interface.StoreDBTransactionInterface, indexable bool) *HashMap {
+	hashMap := CreateNewHashMap(dbTx, "testMap", cryptography.PublicKeyHashSize, indexable)
+	hashMap.CreateObject = func(key []byte, index uint64) (HashMapElementSerializableInterface, error) {
+		return &testElement{Key: key, index: index}, nil
+	}
+	return hashMap
+}
+
+//returns the sorted keys of the stored elements
+func createTestHashMapStore(t *testing.T, indexable bool, count int) (*store_db_memory.StoreDBMemory, []string) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("")
+	assert.NoError(t, err)
+
+	keys := make([]string, count)
+	assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+		hashMap := createTestHashMap(dbTx, indexable)
+		for i := range keys {
+			keys[i] = string(helpers.RandomBytes(cryptography.PublicKeyHashSize))
+			assert.NoError(t, hashMap.Create(keys[i], &testElement{Value: uint64(i)}))
+		}
+		return hashMap.CommitChanges()
+	}))
+	sort.Strings(keys)
+
+	return db, keys
+}
+
+func TestHashMap_Iterate(t *testing.T) {
+
+	//more elements than a chunk of the iteration
+	db, keys := createTestHashMapStore(t, false, 250)
+
+	assert.NoError(t, db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+
+		hashMap := createTestHashMap(dbTx, false)
+
+		iterated := []string{}
+		assert.NoError(t, hashMap.Iterate("", false, func(key string, element HashMapElementSerializableInterface) (bool, error) {
+			assert.Equal(t, key, string(element.(*testElement).Key))
+
+			//the store can be read by the callback
+			found, err := hashMap.Get(key)
+			assert.NoError(t, err)
+			assert.Equal(t, found.(*testElement).Value, element.(*testElement).Value)
+
+			iterated = append(iterated, key)
+			return true, nil
+		}))
+		assert.Equal(t, keys, iterated)
+
+		iterated = []string{}
+		assert.NoError(t, hashMap.Iterate(keys[len(keys)/2], true, func(key string, element HashMapElementSerializableInterface) (bool, error) {
+			iterated = append(iterated, key)
+			return len(iterated) < 10, nil
+		}))
+		assert.Equal(t, 10, len(iterated))
+		for i := range iterated {
+			assert.Equal(t, keys[len(keys)/2-i], iterated[i])
+		}
+
+		//the start key doesn't have to exist
+		start := keys[100] + "\x00"
+		iterated = []string{}
+		assert.NoError(t, hashMap.Iterate(start, false, func(key string, element HashMapElementSerializableInterface) (bool, error) {
+			iterated = append(iterated, key)
+			return true, nil
+		}))
+		assert.Equal(t, keys[101:], iterated)
+
+		return nil
+	}))
+
+	assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+		hashMap := createTestHashMap(dbTx, false)
+		hashMap.Delete(keys[0])
+		assert.EqualError(t, hashMap.Iterate("", false, func(key string, element HashMapElementSerializableInterface) (bool, error) {
+			return true, nil
+		}), "Iterate is supported only when is committed")
+		return nil
+	}))
+}
+
+func TestHashMap_Verify(t *testing.T) {
+
+	for _, indexable := range []bool{false, true} {
+
+		db, keys := createTestHashMapStore(t, indexable, 50)
+
+		assert.NoError(t, db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			count, mismatches, err := createTestHashMap(dbTx, indexable).Verify()
+			assert.NoError(t, err)
+			assert.Empty(t, mismatches)
+			assert.Equal(t, uint64(len(keys)), count)
+			return nil
+		}))
+
+		//the corrupted store is not written, so every case starts from the valid store
+		corrupt := func(callback func(dbTx store_db_interface.StoreDBTransactionInterface), expected int) {
+			assert.EqualError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+				callback(dbTx)
+				_, mismatches, err := createTestHashMap(dbTx, indexable).Verify()
+				assert.NoError(t, err)
+				assert.Equal(t, expected, len(mismatches), mismatches)
+				return errors.New("rollback")
+			}), "rollback")
+		}
+
+		//the count and the data without the key
+		corrupt(func(dbTx store_db_interface.StoreDBTransactionInterface) {
+			dbTx.Delete("testMap:exists:" + keys[0])
+		}, map[bool]int{false: 2, true: 5}[indexable])
+
+		//the data of an existing key is missing
+		corrupt(func(dbTx store_db_interface.StoreDBTransactionInterface) {
+			dbTx.Delete("testMap:map:" + keys[0])
+		}, 1)
+
+		if indexable {
+			//the list points to another key
+			corrupt(func(dbTx store_db_interface.StoreDBTransactionInterface) {
+				dbTx.Put("testMap:list:0", []byte(keys[1]))
+				dbTx.Put("testMap:list:1", []byte(keys[0]))
+			}, 2)
+
+			//an index above the count
+			corrupt(func(dbTx store_db_interface.StoreDBTransactionInterface) {
+				dbTx.Put("testMap:list:"+strconv.Itoa(len(keys)), []byte(keys[0]))
+			}, 2)
+		}
+
+		//the changes of an uncommitted map can not be verified
+		assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			hashMap := createTestHashMap(dbTx, indexable)
+			hashMap.Delete(keys[0])
+			_, _, err := hashMap.Verify()
+			assert.EqualError(t, err, "Verify is supported only when is committed")
+			return nil
+		}))
+	}
+}
@@ -0,0 +1,100 @@
+package hash_map
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+//support only for commited data. It walks the stored entries and reports the mismatches between :count, :exists:, :map: and the :list: / :listKeys: indexes
+func (hashMap *HashMap) Verify() (count uint64, mismatches []string, err error) {
+
+	if hashMap.changed {
+		return 0, nil, errors.New("Verify is supported only when is committed")
+	}
+
+	report := func(format string, args ...interface{}) {
+		mismatches = append(mismatches, hashMap.name+": "+fmt.Sprintf(format, args...))
+	}
+	keyStr := func(key string) string {
+		return base64.StdEncoding.EncodeToString([]byte(key))
+	}
+
+	prefix := hashMap.name + ":exists:"
+	if err = hashMap.Tx.Iterate(prefix, "", false, func(key string, value []byte) bool {
+		key = key[len(prefix):]
+		if hashMap.keyLength != 0 && len(key) != hashMap.keyLength {
+			report("key %s has an invalid length", keyStr(key))
+		}
+		if !hashMap.Tx.Exists(hashMap.name + ":map:" + key) {
+			report("key %s exists but its data is missing", keyStr(key))
+		}
+		count += 1
+		return true
+	}); err != nil {
+		return
+	}
+
+	var stored uint64
+	if buffer := hashMap.Tx.Get(hashMap.name + ":count"); buffer != nil {
+		var p int
+		if stored, p = binary.Uvarint(buffer); p <= 0 {
+			report("count can not be read")
+		}
+	}
+	if stored != count {
+		report("count is %d but %d entries exist", stored, count)
+	}
+
+	prefix = hashMap.name + ":map:"
+	if err = hashMap.Tx.Iterate(prefix, "", false, func(key string, value []byte) bool {
+		key = key[len(prefix):]
+		if !hashMap.Tx.Exists(hashMap.name + ":exists:" + key) {
+			report("key %s has data but it doesn't exist", keyStr(key))
+		}
+		return true
+	}); err != nil {
+		return
+	}
+
+	if !hashMap.Indexable {
+		return
+	}
+
+	var listKeysCount uint64
+	prefix = hashMap.name + ":listKeys:"
+	if err = hashMap.Tx.Iterate(prefix, "", false, func(key string, value []byte) bool {
+		key = key[len(prefix):]
+		listKeysCount += 1
+		if !hashMap.Tx.Exists(hashMap.name + ":exists:" + key) {
+			report("key %s is indexed but it doesn't exist", keyStr(key))
+		}
+		if string(hashMap.Tx.Get(hashMap.name+":list:"+string(value))) != key {
+			report("key %s is indexed at %s but the list doesn't match", keyStr(key), string(value))
+		}
+		return true
+	}); err != nil {
+		return
+	}
+
+	var listCount uint64
+	prefix = hashMap.name + ":list:"
+	if err = hashMap.Tx.Iterate(prefix, "", false, func(key string, value []byte) bool {
+		listCount += 1
+		index, err2 := strconv.ParseUint(key[len(prefix):], 10, 64)
+		if err2 != nil || index >= count {
+			report("list index %s is out of range", key[len(prefix):])
+		}
+		return true
+	}); err != nil {
+		return
+	}
+
+	if listKeysCount != count || listCount != count {
+		report("%d entries exist but %d are in the list and %d in listKeys", count, listCount, listKeysCount)
+	}
+
+	return
+}
@@ -1,5 +1,7 @@
 package min_max_heap
 
+import "strconv"
+
 // based on https://golangbyexample.com/Heap-in-golang/
 
 type Heap struct {
@@ -137,11 +139,16 @@ func (m *Heap) Delete(index uint64) error {
 		return err
 	}
 
+	//the last element was deleted
+	if index == m.GetSize() {
+		return nil
+	}
+
 	if err = m.updateElement(index, element); err != nil {
 		return err
 	}
 
-	if index > 1 {
+	if index > 0 {
 		p, err := m.getElement(m.parent(index))
 		if err != nil {
 			return err
@@ -178,6 +185,61 @@ func (m *Heap) GetTop() (*HeapElement, error) {
 	return m.getElement(0)
 }
 
+//returns the elements ranked between start and start+count without changing the heap
+func (m *Heap) GetTopElements(start, count uint64) ([]*HeapElement, error) {
+
+	out := make([]*HeapElement, 0)
+	if m.GetSize() == 0 {
+		return out, nil
+	}
+
+	root, err := m.getElement(0)
+	if err != nil {
+		return nil, err
+	}
+
+	//the candidates are the children of the elements already visited
+	candidates := NewHeapMemory(m.compare)
+	if err = candidates.Insert(root.Score, []byte(strconv.FormatUint(0, 10))); err != nil {
+		return nil, err
+	}
+
+	for rank := uint64(0); rank < start+count && candidates.GetSize() > 0; rank++ {
+
+		top, err := candidates.RemoveTop()
+		if err != nil {
+			return nil, err
+		}
+
+		index, err := strconv.ParseUint(string(top.Key), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if rank >= start {
+			var element *HeapElement
+			if element, err = m.getElement(index); err != nil {
+				return nil, err
+			}
+			out = append(out, element)
+		}
+
+		for _, child := range []uint64{m.leftchild(index), m.rightchild(index)} {
+			if child < m.GetSize() {
+				var element *HeapElement
+				if element, err = m.getElement(child); err != nil {
+					return nil, err
+				}
+				if err = candidates.Insert(element.Score, []byte(strconv.FormatUint(child, 10))); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
+
 /*
 Minheap
 
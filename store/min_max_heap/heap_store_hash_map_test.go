@@ -0,0 +1,87 @@
+package min_max_heap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sort"
+	"testing"
+)
+
+func TestHeapStoreHashMap_GetTopElements(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("heap")
+	assert.NoError(t, err)
+
+	scores := make(map[string]float64)
+
+	err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		heap := NewMaxHeapStoreHashMap(dbTx, "holders")
+
+		for i := 0; i < 200; i++ {
+			key := cryptography.RandomHash()[:20]
+			scores[string(key)] = float64(rand.Intn(1000000))
+			assert.NoError(t, heap.Insert(scores[string(key)], key))
+		}
+
+		//update and delete some keys, including the last element
+		c := 0
+		for key := range scores {
+			if c%3 == 0 {
+				assert.NoError(t, heap.DeleteByKey([]byte(key)))
+				delete(scores, key)
+			} else if c%3 == 1 {
+				assert.NoError(t, heap.DeleteByKey([]byte(key)))
+				scores[key] = float64(rand.Intn(1000000))
+				assert.NoError(t, heap.Insert(scores[key], []byte(key)))
+			}
+			c += 1
+			if c == 60 {
+				break
+			}
+		}
+
+		last, err := heap.getElement(heap.GetSize() - 1)
+		assert.NoError(t, err)
+		assert.NoError(t, heap.DeleteByKey(last.Key))
+		delete(scores, string(last.Key))
+
+		assert.NoError(t, heap.HashMap.CommitChanges())
+		assert.NoError(t, heap.DictMap.CommitChanges())
+		return
+	})
+	assert.NoError(t, err)
+
+	sorted := make([]float64, 0, len(scores))
+	for _, score := range scores {
+		sorted = append(sorted, score)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	err = db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		heap := NewMaxHeapStoreHashMap(dbTx, "holders")
+		assert.Equal(t, uint64(len(scores)), heap.GetSize())
+
+		top, err := heap.GetTopElements(0, uint64(len(scores)))
+		assert.NoError(t, err)
+		assert.Equal(t, len(scores), len(top))
+		for i := range top {
+			assert.Equal(t, sorted[i], top[i].Score)
+			assert.Equal(t, scores[string(top[i].Key)], top[i].Score)
+		}
+
+		page, err := heap.GetTopElements(10, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, len(page))
+		for i := range page {
+			assert.Equal(t, sorted[10+i], page[i].Score)
+		}
+
+		return
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,317 @@
+package state_tree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+/**
+Sparse Merkle Tree over the hashes of the keys
+Every leaf is stored at the shallowest depth where it is alone in its subtree, so the shape of the tree
+depends only on the stored keys and not on the order in which they were inserted or deleted
+The leaves also store the original keys (not hashed) and the tree can be iterated
+*/
+
+const (
+	nodeLeaf     = byte(0)
+	nodeInternal = byte(1)
+)
+
+var EmptyHash = make([]byte, cryptography.HashSize)
+
+type StateTree struct {
+	name  string
+	Tx    store_db_interface.StoreDBTransactionInterface
+	nodes map[string][]byte //changed nodes, nil if they were deleted. Required when the Tx is not writable
+}
+
+type stateTreeNode struct {
+	leaf    bool
+	keyHash []byte
+	hash    []byte
+}
+
+func getBit(keyHash []byte, depth int) byte {
+	return (keyHash[depth/8] >> (7 - uint(depth%8))) & 1
+}
+
+func setBit(keyHash []byte, depth int, bit byte) []byte {
+	out := helpers.CloneBytes(keyHash)
+	if bit == 1 {
+		out[depth/8] |= 1 << (7 - uint(depth%8))
+	} else {
+		out[depth/8] &^= 1 << (7 - uint(depth%8))
+	}
+	return out
+}
+
+//the position of a node is given by its depth and the first depth bits of the key hash
+func getPosition(keyHash []byte, depth int) string {
+
+	prefix := make([]byte, 2+(depth+7)/8)
+	binary.BigEndian.PutUint16(prefix, uint16(depth))
+	copy(prefix[2:], keyHash[:(depth+7)/8])
+
+	if depth%8 != 0 {
+		prefix[len(prefix)-1] &= byte(0xff << (8 - uint(depth%8)))
+	}
+
+	return string(prefix)
+}
+
+func hashLeaf(keyHash, valueHash []byte) []byte {
+	return cryptography.SHA3(append(append([]byte{nodeLeaf}, keyHash...), valueHash...))
+}
+
+func hashInternal(left, right []byte) []byte {
+	return cryptography.SHA3(append(append([]byte{nodeInternal}, left...), right...))
+}
+
+func (tree *StateTree) getNode(keyHash []byte, depth int) (*stateTreeNode, error) {
+
+	position := getPosition(keyHash, depth)
+
+	data, exists := tree.nodes[position]
+	if !exists {
+		//clone required because data could be altered afterwards
+		data = helpers.CloneBytes(tree.Tx.Get(tree.name + ":node:" + position))
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	switch {
+	case len(data) >= 1+2*cryptography.HashSize && data[0] == nodeLeaf:
+		return &stateTreeNode{true, data[1 : 1+cryptography.HashSize], hashLeaf(data[1:1+cryptography.HashSize], data[1+cryptography.HashSize:])}, nil
+	case len(data) == 1+cryptography.HashSize && data[0] == nodeInternal:
+		return &stateTreeNode{false, nil, data[1:]}, nil
+	default:
+		return nil, errors.New("State Tree node is invalid")
+	}
+}
+
+func (tree *StateTree) getNodeHash(keyHash []byte, depth int) ([]byte, error) {
+	node, err := tree.getNode(keyHash, depth)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return EmptyHash, nil
+	}
+	return node.hash, nil
+}
+
+func (tree *StateTree) putNode(keyHash []byte, depth int, data []byte) {
+	position := getPosition(keyHash, depth)
+	tree.nodes[position] = data
+	if tree.Tx.IsWritable() {
+		tree.Tx.Put(tree.name+":node:"+position, data)
+	}
+}
+
+func (tree *StateTree) putLeaf(keyHash []byte, depth int, valueHash, key []byte) {
+	tree.putNode(keyHash, depth, append(append(append([]byte{nodeLeaf}, keyHash...), valueHash...), key...))
+}
+
+func (tree *StateTree) deleteNode(keyHash []byte, depth int) {
+	position := getPosition(keyHash, depth)
+	tree.nodes[position] = nil
+	if tree.Tx.IsWritable() {
+		tree.Tx.Delete(tree.name + ":node:" + position)
+	}
+}
+
+//the leaf was moved and the leaf data is required
+func (tree *StateTree) getLeafData(keyHash []byte, depth int) []byte {
+	position := getPosition(keyHash, depth)
+	if data, exists := tree.nodes[position]; exists {
+		return data
+	}
+	return helpers.CloneBytes(tree.Tx.Get(tree.name + ":node:" + position))
+}
+
+//recomputes the hashes of the internal nodes from the path of the key hash
+func (tree *StateTree) rehashPath(keyHash []byte) error {
+
+	depths := make([]int, 0)
+	for depth := 0; depth < cryptography.HashSize*8; depth++ {
+		node, err := tree.getNode(keyHash, depth)
+		if err != nil {
+			return err
+		}
+		if node == nil || node.leaf {
+			break
+		}
+		depths = append(depths, depth)
+	}
+
+	for i := len(depths) - 1; i >= 0; i-- {
+
+		depth := depths[i]
+
+		left, err := tree.getNodeHash(setBit(keyHash, depth, 0), depth+1)
+		if err != nil {
+			return err
+		}
+		right, err := tree.getNodeHash(setBit(keyHash, depth, 1), depth+1)
+		if err != nil {
+			return err
+		}
+
+		tree.putNode(keyHash, depth, append([]byte{nodeInternal}, hashInternal(left, right)...))
+	}
+
+	return nil
+}
+
+func (tree *StateTree) Update(key, value []byte) error {
+
+	keyHash := cryptography.SHA3(key)
+	valueHash := cryptography.SHA3(value)
+
+	for depth := 0; depth < cryptography.HashSize*8; depth++ {
+
+		node, err := tree.getNode(keyHash, depth)
+		if err != nil {
+			return err
+		}
+
+		if node == nil || (node.leaf && bytes.Equal(node.keyHash, keyHash)) {
+			tree.putLeaf(keyHash, depth, valueHash, key)
+			return tree.rehashPath(keyHash)
+		}
+
+		if node.leaf {
+
+			//the existing leaf is pushed down until the two keys are diverging
+			other := tree.getLeafData(keyHash, depth)
+			otherKeyHash := node.keyHash
+
+			for ; depth < cryptography.HashSize*8; depth++ {
+				tree.putNode(keyHash, depth, append([]byte{nodeInternal}, EmptyHash...))
+				if getBit(keyHash, depth) != getBit(otherKeyHash, depth) {
+					tree.putNode(otherKeyHash, depth+1, other)
+					tree.putLeaf(keyHash, depth+1, valueHash, key)
+					return tree.rehashPath(keyHash)
+				}
+			}
+
+			return errors.New("State Tree key hash collision")
+		}
+
+	}
+
+	return errors.New("State Tree is too deep")
+}
+
+func (tree *StateTree) Delete(key []byte) error {
+
+	keyHash := cryptography.SHA3(key)
+
+	depth := 0
+	for ; depth < cryptography.HashSize*8; depth++ {
+
+		node, err := tree.getNode(keyHash, depth)
+		if err != nil {
+			return err
+		}
+
+		if node == nil || (node.leaf && !bytes.Equal(node.keyHash, keyHash)) {
+			return nil
+		}
+
+		if node.leaf {
+			tree.deleteNode(keyHash, depth)
+			break
+		}
+	}
+
+	//the remaining leaf is moved up while it is alone in its subtree
+	for ; depth > 0; depth-- {
+
+		sibling := setBit(keyHash, depth-1, 1-getBit(keyHash, depth-1))
+
+		current, err := tree.getNode(keyHash, depth)
+		if err != nil {
+			return err
+		}
+		other, err := tree.getNode(sibling, depth)
+		if err != nil {
+			return err
+		}
+
+		if current != nil && other != nil {
+			break
+		}
+
+		var leaf *stateTreeNode
+		var leafKeyHash []byte
+		if current != nil {
+			leaf, leafKeyHash = current, keyHash
+		} else if other != nil {
+			leaf, leafKeyHash = other, sibling
+		}
+
+		if leaf != nil && !leaf.leaf {
+			break
+		}
+
+		if leaf == nil {
+			tree.deleteNode(keyHash, depth-1)
+		} else {
+			data := tree.getLeafData(leafKeyHash, depth)
+			tree.deleteNode(leafKeyHash, depth)
+			tree.putNode(keyHash, depth-1, data)
+		}
+	}
+
+	return tree.rehashPath(keyHash)
+}
+
+//iterates the keys of the leaves in the order of the key hashes
+func (tree *StateTree) Iterate(callback func(key []byte) error) error {
+	return tree.iterate(EmptyHash, 0, callback)
+}
+
+func (tree *StateTree) iterate(keyHash []byte, depth int, callback func(key []byte) error) error {
+
+	node, err := tree.getNode(keyHash, depth)
+	if err != nil || node == nil {
+		return err
+	}
+
+	if node.leaf {
+		data := tree.getLeafData(keyHash, depth)
+		return callback(data[1+2*cryptography.HashSize:])
+	}
+
+	if depth == cryptography.HashSize*8 {
+		return errors.New("State Tree is too deep")
+	}
+
+	if err = tree.iterate(setBit(keyHash, depth, 0), depth+1, callback); err != nil {
+		return err
+	}
+	return tree.iterate(setBit(keyHash, depth, 1), depth+1, callback)
+}
+
+func (tree *StateTree) GetRoot() ([]byte, error) {
+	return tree.getNodeHash(EmptyHash, 0)
+}
+
+func (tree *StateTree) SetTx(dbTx store_db_interface.StoreDBTransactionInterface) {
+	tree.Tx = dbTx
+}
+
+func CreateNewStateTree(tx store_db_interface.StoreDBTransactionInterface, name string) *StateTree {
+	return &StateTree{
+		name,
+		tx,
+		make(map[string][]byte),
+	}
+}
@@ -0,0 +1,109 @@
+package state_tree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestStateTree_Canonical(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("stateTree")
+	assert.NoError(t, err)
+
+	keys := make([][]byte, 100)
+	values := make([][]byte, len(keys))
+	for i := range keys {
+		keys[i] = cryptography.RandomHash()
+		values[i] = cryptography.RandomHash()
+	}
+
+	var root1, root2, root3 []byte
+
+	err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		tree := CreateNewStateTree(dbTx, "tree1")
+
+		empty, err := tree.GetRoot()
+		assert.NoError(t, err)
+		assert.Equal(t, EmptyHash, empty)
+
+		for i := range keys {
+			assert.NoError(t, tree.Update(keys[i], values[i]))
+		}
+		root1, err = tree.GetRoot()
+		assert.NoError(t, err)
+
+		//the same keys inserted in a different order
+		tree2 := CreateNewStateTree(dbTx, "tree2")
+		for _, i := range rand.Perm(len(keys)) {
+			assert.NoError(t, tree2.Update(keys[i], values[i]))
+		}
+		root2, err = tree2.GetRoot()
+		assert.NoError(t, err)
+
+		//adding and deleting new keys should revert to the same root
+		extra := make([][]byte, 30)
+		for i := range extra {
+			extra[i] = cryptography.RandomHash()
+			assert.NoError(t, tree2.Update(extra[i], extra[i]))
+		}
+		assert.NoError(t, tree2.Update(keys[0], []byte{1}))
+
+		root3, err = tree2.GetRoot()
+		assert.NoError(t, err)
+		assert.NotEqual(t, root1, root3)
+
+		for _, i := range rand.Perm(len(extra)) {
+			assert.NoError(t, tree2.Delete(extra[i]))
+		}
+		assert.NoError(t, tree2.Update(keys[0], values[0]))
+
+		root3, err = tree2.GetRoot()
+		assert.NoError(t, err)
+
+		//the iteration returns all the keys
+		found := make(map[string]bool)
+		assert.NoError(t, tree2.Iterate(func(key []byte) error {
+			found[string(key)] = true
+			return nil
+		}))
+		assert.Equal(t, len(keys), len(found))
+		for i := range keys {
+			assert.True(t, found[string(keys[i])])
+		}
+
+		for i := range keys {
+			assert.NoError(t, tree2.Delete(keys[i]))
+		}
+		empty, err = tree2.GetRoot()
+		assert.NoError(t, err)
+		assert.Equal(t, EmptyHash, empty)
+
+		return
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, root1, root2)
+	assert.Equal(t, root1, root3)
+
+	//the nodes were stored and the tree can be read again
+	err = db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+		tree := CreateNewStateTree(dbTx, "tree1")
+		root, err := tree.GetRoot()
+		assert.NoError(t, err)
+		assert.Equal(t, root1, root)
+
+		//changes are kept in memory when the tx is not writable
+		assert.NoError(t, tree.Delete(keys[1]))
+		root, err = tree.GetRoot()
+		assert.NoError(t, err)
+		assert.NotEqual(t, root1, root)
+		return
+	})
+	assert.NoError(t, err)
+
+}
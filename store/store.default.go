@@ -5,27 +5,41 @@ package store
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"pandora-pay/config"
 	"pandora-pay/config/globals"
 	"pandora-pay/store/store_db/store_db_bolt"
 	"pandora-pay/store/store_db/store_db_bunt"
 	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_leveldb"
 	"pandora-pay/store/store_db/store_db_memory"
+	"path/filepath"
+	"strings"
 )
 
+var storeLock *os.File //held while the stores are opened, so two processes can't use the same store folder
+
 func createStoreNow(name, storeType string) (*Store, error) {
 
 	var db store_db_interface.StoreDBInterface
 	var err error
 
+	path := filepath.Join(config.STORE_PATH, strings.TrimPrefix(name, "/")+"_store."+storeType)
+
 	switch storeType {
 	case "bolt":
-		db, err = store_db_bolt.CreateStoreDBBolt(name)
+		db, err = store_db_bolt.CreateStoreDBBolt(name, path)
 	case "bunt":
-		db, err = store_db_bunt.CreateStoreDBBunt(name, false)
+		db, err = store_db_bunt.CreateStoreDBBunt(name, path)
 	case "bunt-memory":
-		db, err = store_db_bunt.CreateStoreDBBunt(name, true)
+		path = ""
+		db, err = store_db_bunt.CreateStoreDBBunt(name, path)
 	case "memory":
+		path = ""
 		db, err = store_db_memory.CreateStoreDBMemory(name)
+	case "leveldb":
+		db, err = store_db_leveldb.CreateStoreDBLevelDB(name, path)
 	default:
 		err = errors.New("Invalid --store-type argument")
 	}
@@ -34,7 +48,7 @@ func createStoreNow(name, storeType string) (*Store, error) {
 		return nil, err
 	}
 
-	store, err := createStore(name, db)
+	store, err := createStore(name, path, db)
 	if err != nil {
 		return nil, err
 	}
@@ -42,15 +56,56 @@ func createStoreNow(name, storeType string) (*Store, error) {
 	return store, nil
 }
 
+func lock_db() (err error) {
+
+	if err = os.MkdirAll(config.STORE_PATH, 0755); err != nil {
+		return
+	}
+
+	file, err := os.OpenFile(filepath.Join(config.STORE_PATH, "store.lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return
+	}
+
+	if err = lockFile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("Error locking %s: %w", config.STORE_PATH, err)
+	}
+
+	storeLock = file
+	return
+}
+
+func unlock_db() error {
+	if storeLock == nil {
+		return nil
+	}
+	return storeLock.Close()
+}
+
 func create_db() (err error) {
 
+	if err = lock_db(); err != nil {
+		return
+	}
+
 	var prefix = ""
 
-	allowedStores := map[string]bool{"bolt": true, "bunt": true, "bunt-memory": true, "memory": true}
+	allowedStores := map[string]bool{"bolt": true, "bunt": true, "bunt-memory": true, "memory": true, "leveldb": true}
 
-	if StoreBlockchain, err = createStoreNow(prefix+"/blockchain", getStoreType(globals.Arguments["--store-chain-type"].(string), allowedStores)); err != nil {
+	chainStoreType := getStoreType(globals.Arguments["--store-chain-type"].(string), allowedStores)
+	if StoreBlockchain, err = createStoreNow(prefix+"/blockchain", chainStoreType); err != nil {
 		return
 	}
+	if globals.Arguments["--store-chain-migrate"] != nil {
+		migrateType := getStoreType(globals.Arguments["--store-chain-migrate"].(string), allowedStores)
+		if migrateType == chainStoreType {
+			return errors.New("--store-chain-migrate must be different than --store-chain-type")
+		}
+		if err = migrateStore(prefix+"/blockchain", migrateType, StoreBlockchain); err != nil {
+			return
+		}
+	}
 	if StoreWallet, err = createStoreNow(prefix+"/wallet", getStoreType(globals.Arguments["--store-wallet-type"].(string), allowedStores)); err != nil {
 		return
 	}
@@ -6,6 +6,7 @@ import (
 
 type Store struct {
 	Name   string
+	Path   string //empty for the stores kept in memory
 	Opened bool
 	DB     store_db_interface.StoreDBInterface
 }
@@ -16,10 +17,11 @@ func (store *Store) close() error {
 	return store.DB.Close()
 }
 
-func createStore(name string, db store_db_interface.StoreDBInterface) (*Store, error) {
+func createStore(name, path string, db store_db_interface.StoreDBInterface) (*Store, error) {
 
 	store := &Store{
 		Name:   name,
+		Path:   path,
 		Opened: false,
 		DB:     db,
 	}
@@ -46,7 +48,7 @@ func DBClose() (err error) {
 	if err = StoreMempool.close(); err != nil {
 		return
 	}
-	return
+	return unlock_db()
 }
 
 func getStoreType(value string, allowed map[string]bool) string {
@@ -19,7 +19,7 @@ func createStoreNow(name string, storeType string) (*Store, error) {
 
 	switch storeType {
 	case "bunt-memory":
-		db, err = store_db_bunt.CreateStoreDBBunt(name, true)
+		db, err = store_db_bunt.CreateStoreDBBunt(name, "")
 	case "js":
 		db, err = store_db_js.CreateStoreDBJS(name)
 	case "memory":
@@ -32,7 +32,11 @@ func createStoreNow(name string, storeType string) (*Store, error) {
 		return nil, err
 	}
 
-	return createStore(name, db)
+	return createStore(name, "", db)
+}
+
+func unlock_db() error {
+	return nil
 }
 
 func create_db() (err error) {
@@ -1,9 +1,10 @@
 package store_db_bolt
 
 import (
+	"fmt"
 	bolt "go.etcd.io/bbolt"
-	"os"
 	"pandora-pay/store/store_db/store_db_interface"
+	"time"
 )
 
 type StoreDBBolt struct {
@@ -37,7 +38,8 @@ func (store *StoreDBBolt) Update(callback func(dbTx store_db_interface.StoreDBTr
 	})
 }
 
-func CreateStoreDBBolt(name string) (*StoreDBBolt, error) {
+//opens the bolt store located at path. bolt holds an exclusive lock on the file, so a second process fails after a timeout instead of blocking forever
+func CreateStoreDBBolt(name, path string) (*StoreDBBolt, error) {
 
 	var err error
 
@@ -45,17 +47,9 @@ func CreateStoreDBBolt(name string) (*StoreDBBolt, error) {
 		Name: []byte(name),
 	}
 
-	prefix := "./store"
-	if _, err = os.Stat(prefix); os.IsNotExist(err) {
-		if err = os.Mkdir(prefix, 0755); err != nil {
-			return nil, err
-		}
-	}
-
-	// Open the my.store data file in your current directory.
 	// It will be created if it doesn't exist.
-	if store.DB, err = bolt.Open(prefix+name+"_store"+".bolt", 0600, nil); err != nil {
-		return nil, err
+	if store.DB, err = bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second}); err != nil {
+		return nil, fmt.Errorf("Error opening store %s: %w", path, err)
 	}
 
 	if err = store.DB.Update(func(tx *bolt.Tx) (err error) {
@@ -1,6 +1,7 @@
 package store_db_bolt
 
 import (
+	"bytes"
 	bolt "go.etcd.io/bbolt"
 	"pandora-pay/helpers"
 	"pandora-pay/store/store_db/store_db_interface"
@@ -36,3 +37,44 @@ func (tx *StoreDBBoltTransaction) Exists(key string) bool {
 func (tx *StoreDBBoltTransaction) Delete(key string) {
 	tx.bucket.Delete([]byte(key))
 }
+
+func (tx *StoreDBBoltTransaction) Iterate(prefix, seek string, reverse bool, callback func(key string, value []byte) bool) error {
+
+	c := tx.bucket.Cursor()
+
+	var k, v []byte
+	if !reverse {
+		start := prefix
+		if seek > start {
+			start = seek
+		}
+		k, v = c.Seek([]byte(start))
+	} else if seek != "" {
+		if k, v = c.Seek([]byte(seek)); k == nil {
+			k, v = c.Last()
+		} else if string(k) != seek {
+			k, v = c.Prev()
+		}
+	} else if end := store_db_interface.PrefixEnd(prefix); end != "" {
+		if k, v = c.Seek([]byte(end)); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+	} else {
+		k, v = c.Last()
+	}
+
+	for k != nil && bytes.HasPrefix(k, []byte(prefix)) {
+		if !callback(string(k), helpers.CloneBytes(v)) {
+			break
+		}
+		if reverse {
+			k, v = c.Prev()
+		} else {
+			k, v = c.Next()
+		}
+	}
+
+	return nil
+}
@@ -2,12 +2,9 @@ package store_db_bunt
 
 import (
 	"github.com/tidwall/buntdb"
-	"os"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
-const dbName = "bunt"
-
 type StoreDBBunt struct {
 	store_db_interface.StoreDBInterface
 	DB   *buntdb.DB
@@ -37,30 +34,21 @@ func (store *StoreDBBunt) Update(callback func(dbTx store_db_interface.StoreDBTr
 	})
 }
 
-func CreateStoreDBBunt(name string, inMemory bool) (*StoreDBBunt, error) {
+//opens the bunt store located at path. An empty path keeps the store in memory
+func CreateStoreDBBunt(name, path string) (*StoreDBBunt, error) {
 
 	var err error
 
-	var prefix string
-	if !inMemory {
-		prefix = "./store"
-		if _, err = os.Stat(prefix); os.IsNotExist(err) {
-			if err = os.Mkdir(prefix, 0755); err != nil {
-				return nil, err
-			}
-		}
-		prefix += name + "_store" + "." + dbName
-	} else {
-		prefix = ":memory:"
+	if path == "" {
+		path = ":memory:"
 	}
 
 	store := &StoreDBBunt{
 		Name: []byte(name),
 	}
 
-	// Open the my.store data file in your current directory.
 	// It will be created if it doesn't exist.
-	if store.DB, err = buntdb.Open(prefix); err != nil {
+	if store.DB, err = buntdb.Open(path); err != nil {
 		return nil, err
 	}
 
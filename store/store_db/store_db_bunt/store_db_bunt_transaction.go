@@ -3,6 +3,7 @@ package store_db_bunt
 import (
 	buntdb "github.com/tidwall/buntdb"
 	"pandora-pay/store/store_db/store_db_interface"
+	"strings"
 )
 
 type StoreDBBuntTransaction struct {
@@ -41,7 +42,38 @@ func (tx *StoreDBBuntTransaction) Exists(key string) bool {
 
 func (tx *StoreDBBuntTransaction) Delete(key string) {
 	_, err := tx.buntTx.Delete(key)
-	if err != buntdb.ErrNotFound {
+	if err != nil && err != buntdb.ErrNotFound {
 		panic(err)
 	}
 }
+
+func (tx *StoreDBBuntTransaction) Iterate(prefix, seek string, reverse bool, callback func(key string, value []byte) bool) error {
+
+	iterator := func(key, value string) bool {
+		if !strings.HasPrefix(key, prefix) {
+			return false
+		}
+		return callback(key, []byte(value))
+	}
+
+	if !reverse {
+		start := prefix
+		if seek > start {
+			start = seek
+		}
+		return tx.buntTx.AscendGreaterOrEqual("", start, iterator)
+	}
+
+	if seek != "" {
+		return tx.buntTx.DescendLessOrEqual("", seek, iterator)
+	}
+	if end := store_db_interface.PrefixEnd(prefix); end != "" {
+		return tx.buntTx.DescendLessOrEqual("", end, func(key, value string) bool {
+			if key == end {
+				return true
+			}
+			return iterator(key, value)
+		})
+	}
+	return tx.buntTx.Descend("", iterator)
+}
@@ -0,0 +1,138 @@
+package store_db_interface_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_bolt"
+	"pandora-pay/store/store_db/store_db_bunt"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_leveldb"
+	"pandora-pay/store/store_db/store_db_memory"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func iterate(t *testing.T, dbTx store_db_interface.StoreDBTransactionInterface, prefix, seek string, reverse bool, limit int) (keys []string) {
+	keys = []string{}
+	assert.NoError(t, dbTx.Iterate(prefix, seek, reverse, func(key string, value []byte) bool {
+		assert.Equal(t, key, string(value))
+		keys = append(keys, key)
+		return len(keys) < limit
+	}))
+	return
+}
+
+func testIterate(t *testing.T, db store_db_interface.StoreDBInterface) {
+
+	assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+		for _, key := range []string{"a", "b:1", "b:2", "b:3", "b;", "c", "b:0"} {
+			dbTx.Put(key, []byte(key))
+		}
+		dbTx.Delete("b:0")
+		return nil
+	}))
+
+	assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+
+		//uncommitted changes are visible
+		dbTx.Put("b:4", []byte("b:4"))
+		dbTx.Delete("b:2")
+
+		assert.Equal(t, []string{"b:1", "b:3", "b:4"}, iterate(t, dbTx, "b:", "", false, 10))
+		assert.Equal(t, []string{"b:4", "b:3", "b:1"}, iterate(t, dbTx, "b:", "", true, 10))
+		assert.Equal(t, []string{"b:3", "b:4"}, iterate(t, dbTx, "b:", "b:2", false, 10))
+		assert.Equal(t, []string{"b:3", "b:1"}, iterate(t, dbTx, "b:", "b:3", true, 10))
+		assert.Equal(t, []string{"b:1"}, iterate(t, dbTx, "b:", "", false, 1))
+		assert.Equal(t, []string{"a", "b:1", "b:3", "b:4", "b;", "c"}, iterate(t, dbTx, "", "", false, 10))
+		assert.Equal(t, []string{"c", "b;", "b:4"}, iterate(t, dbTx, "", "", true, 3))
+		assert.Equal(t, []string{}, iterate(t, dbTx, "d", "", false, 10))
+		assert.Equal(t, []string{}, iterate(t, dbTx, "d", "", true, 10))
+
+		return nil
+	}))
+
+	assert.NoError(t, db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, []string{"b:1", "b:3", "b:4"}, iterate(t, dbTx, "b:", "", false, 10))
+		return nil
+	}))
+}
+
+func createStores(t testing.TB, name string) []store_db_interface.StoreDBInterface {
+
+	memory, err := store_db_memory.CreateStoreDBMemory(name)
+	assert.NoError(t, err)
+
+	bunt, err := store_db_bunt.CreateStoreDBBunt(name, "")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+
+	bolt, err := store_db_bolt.CreateStoreDBBolt(name, filepath.Join(dir, name+"_store.bolt"))
+	assert.NoError(t, err)
+
+	leveldb, err := store_db_leveldb.CreateStoreDBLevelDB(name, filepath.Join(dir, name+"_store.leveldb"))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		bolt.Close()
+		leveldb.Close()
+	})
+
+	return []store_db_interface.StoreDBInterface{memory, bunt, bolt, leveldb}
+}
+
+func TestIterate(t *testing.T) {
+	for _, db := range createStores(t, "iterate") {
+		testIterate(t, db)
+	}
+}
+
+func TestUpdateRollback(t *testing.T) {
+	for _, db := range createStores(t, "rollback") {
+
+		assert.NoError(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			dbTx.Put("a", []byte{1})
+			return nil
+		}))
+
+		assert.Error(t, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			dbTx.Put("b", []byte{2})
+			dbTx.Delete("a")
+			assert.Nil(t, dbTx.Get("a"))
+			assert.Equal(t, []byte{2}, dbTx.Get("b"))
+			return errors.New("Rollback")
+		}))
+
+		assert.NoError(t, db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			assert.Equal(t, []byte{1}, dbTx.Get("a"))
+			assert.False(t, dbTx.Exists("b"))
+			return nil
+		}))
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, "b;", store_db_interface.PrefixEnd("b:"))
+	assert.Equal(t, "b", store_db_interface.PrefixEnd("a\xff\xff"))
+	assert.Equal(t, "", store_db_interface.PrefixEnd("\xff"))
+	assert.Equal(t, "", store_db_interface.PrefixEnd(""))
+}
+
+//writes small random keys like the hash maps do when the blocks are included
+func BenchmarkUpdate(b *testing.B) {
+	for _, db := range createStores(b, "benchmark") {
+		b.Run(reflect.TypeOf(db).Elem().Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				assert.NoError(b, db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+					for j := 0; j < 1000; j++ {
+						key := cryptography.RandomHash()
+						dbTx.Put("accounts:map:"+string(key[:20]), key)
+					}
+					return nil
+				}))
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package store_db_interface
+
+import (
+	"sort"
+	"strings"
+)
+
+//returns the smallest key greater than all the keys starting with prefix. An empty string means there is no such key
+func PrefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i] += 1
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
+
+//filters and sorts the keys of the backends which don't keep the keys ordered
+func SortKeys(keys []string, prefix, seek string, reverse bool) []string {
+
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if seek != "" && ((!reverse && key < seek) || (reverse && key > seek)) {
+			continue
+		}
+		out = append(out, key)
+	}
+
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(out)))
+	} else {
+		sort.Strings(out)
+	}
+
+	return out
+}
@@ -6,4 +6,6 @@ type StoreDBTransactionInterface interface {
 	Exists(key string) bool
 	Delete(key string)
 	IsWritable() bool
+	//iterates in the byte order of the keys the entries starting with prefix until the callback returns false. The iteration starts from the seek key (included) if it is set, otherwise from the first key, or from the last one in reverse. The store must not be changed inside the callback
+	Iterate(prefix, seek string, reverse bool, callback func(key string, value []byte) bool) error
 }
@@ -151,3 +151,62 @@ func (tx *StoreDBJSTransaction) writeTx() error {
 
 	return nil
 }
+
+//localforage has no key ranges, hence every call reads all the keys of the store and sorts the ones matching the prefix. The cost is O(total keys) per call, also for every chunk of HashMap.Iterate, so large scans should be avoided in the browser
+func (tx *StoreDBJSTransaction) Iterate(prefix, seek string, reverse bool, callback func(key string, value []byte) bool) error {
+
+	respCh := make(chan []string)
+	defer close(respCh)
+
+	errCh := make(chan error)
+	defer close(errCh)
+
+	promise := tx.jsStore.Call("keys")
+
+	promise.Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		var result []string
+		if !args[0].IsNull() && !args[0].IsUndefined() {
+			result = make([]string, args[0].Length())
+			for i := range result {
+				result[i] = args[0].Index(i).String()
+			}
+		}
+		respCh <- result
+		return nil
+	}), js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		errCh <- fmt.Errorf("error reading keys js db %s", args[0].Get("message").String())
+		return nil
+	}))
+
+	var keys []string
+	select {
+	case keys = <-respCh:
+	case err := <-errCh:
+		return err
+	}
+
+	exists := make(map[string]bool)
+	for _, key := range keys {
+		exists[key] = true
+	}
+	tx.local.Range(func(key string, data *StoreDBJSTransactionData) bool {
+		if data.operation == "put" && !exists[key] {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	for _, key := range store_db_interface.SortKeys(keys, prefix, seek, reverse) {
+
+		value := tx.Get(key)
+		if value == nil {
+			continue
+		}
+
+		if !callback(key, helpers.CloneBytes(value)) {
+			break
+		}
+	}
+
+	return nil
+}
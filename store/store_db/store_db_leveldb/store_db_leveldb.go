@@ -0,0 +1,78 @@
+package store_db_leveldb
+
+import (
+	"fmt"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sync"
+)
+
+type StoreDBLevelDB struct {
+	store_db_interface.StoreDBInterface
+	DB    *leveldb.DB
+	Name  []byte
+	mutex *sync.Mutex //single writer like bolt
+}
+
+func (store *StoreDBLevelDB) Close() error {
+	return store.DB.Close()
+}
+
+//the snapshot gives the same isolation as a bolt read transaction
+func (store *StoreDBLevelDB) View(callback func(dbTx store_db_interface.StoreDBTransactionInterface) error) error {
+
+	snapshot, err := store.DB.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	tx := &StoreDBLevelDBTransaction{
+		snapshot: snapshot,
+		local:    &generics.Map[string, *StoreDBLevelDBTransactionData]{},
+	}
+	return callback(tx)
+}
+
+//the changes are kept in memory and written atomically in a single batch only if the callback returns no error
+func (store *StoreDBLevelDB) Update(callback func(dbTx store_db_interface.StoreDBTransactionInterface) error) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	snapshot, err := store.DB.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	tx := &StoreDBLevelDBTransaction{
+		snapshot: snapshot,
+		local:    &generics.Map[string, *StoreDBLevelDBTransactionData]{},
+		write:    true,
+	}
+
+	if err = callback(tx); err != nil {
+		return err
+	}
+
+	return store.DB.Write(tx.batch(), &opt.WriteOptions{Sync: true})
+}
+
+//opens the leveldb store located in the path folder. leveldb locks the folder by itself
+func CreateStoreDBLevelDB(name, path string) (*StoreDBLevelDB, error) {
+
+	var err error
+
+	store := &StoreDBLevelDB{
+		Name:  []byte(name),
+		mutex: &sync.Mutex{},
+	}
+
+	if store.DB, err = leveldb.OpenFile(path, nil); err != nil {
+		return nil, fmt.Errorf("Error opening store %s: %w", path, err)
+	}
+
+	return store, nil
+}
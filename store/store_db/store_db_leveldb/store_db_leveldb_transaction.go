@@ -0,0 +1,164 @@
+package store_db_leveldb
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strings"
+)
+
+type StoreDBLevelDBTransactionData struct {
+	value     []byte
+	operation string
+}
+
+type StoreDBLevelDBTransaction struct {
+	store_db_interface.StoreDBTransactionInterface
+	snapshot *leveldb.Snapshot
+	write    bool
+	local    *generics.Map[string, *StoreDBLevelDBTransactionData]
+}
+
+func (tx *StoreDBLevelDBTransaction) IsWritable() bool {
+	return tx.write
+}
+
+func (tx *StoreDBLevelDBTransaction) Put(key string, value []byte) {
+	if !tx.write {
+		panic("Transaction is not writeable")
+	}
+	tx.local.Store(key, &StoreDBLevelDBTransactionData{helpers.CloneBytes(value), "put"})
+}
+
+func (tx *StoreDBLevelDBTransaction) Get(key string) []byte {
+
+	if data, ok := tx.local.Load(key); ok {
+		if data.operation == "del" {
+			return nil
+		}
+		return helpers.CloneBytes(data.value)
+	}
+
+	//leveldb returns a copy of the value
+	data, err := tx.snapshot.Get([]byte(key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	} else if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func (tx *StoreDBLevelDBTransaction) Exists(key string) bool {
+
+	if data, ok := tx.local.Load(key); ok {
+		return data.operation != "del"
+	}
+
+	exists, err := tx.snapshot.Has([]byte(key), nil)
+	if err != nil {
+		panic(err)
+	}
+	return exists
+}
+
+func (tx *StoreDBLevelDBTransaction) Delete(key string) {
+	if !tx.write {
+		panic("Transaction is not writeable")
+	}
+	tx.local.Store(key, &StoreDBLevelDBTransactionData{nil, "del"})
+}
+
+//the entries of the snapshot are merged with the changes not written yet
+func (tx *StoreDBLevelDBTransaction) Iterate(prefix, seek string, reverse bool, callback func(key string, value []byte) bool) error {
+
+	localKeys := make([]string, 0)
+	tx.local.Range(func(key string, data *StoreDBLevelDBTransactionData) bool {
+		localKeys = append(localKeys, key)
+		return true
+	})
+	localKeys = store_db_interface.SortKeys(localKeys, prefix, seek, reverse)
+
+	it := tx.snapshot.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	defer it.Release()
+
+	var valid bool
+	if !reverse {
+		if seek > prefix {
+			valid = it.Seek([]byte(seek))
+		} else {
+			valid = it.First()
+		}
+	} else if seek != "" {
+		if valid = it.Seek([]byte(seek)); !valid {
+			valid = it.Last()
+		} else if string(it.Key()) != seek {
+			valid = it.Prev()
+		}
+	} else {
+		valid = it.Last()
+	}
+
+	next := func() bool {
+		if reverse {
+			return it.Prev()
+		}
+		return it.Next()
+	}
+
+	i := 0
+	for valid || i < len(localKeys) {
+
+		var key string
+		var value []byte
+
+		compare := 1
+		if valid && i < len(localKeys) {
+			if compare = strings.Compare(localKeys[i], string(it.Key())); reverse {
+				compare = -compare
+			}
+		} else if i < len(localKeys) {
+			compare = -1
+		}
+
+		if compare <= 0 {
+			key = localKeys[i]
+			data, _ := tx.local.Load(key)
+			value = helpers.CloneBytes(data.value)
+			i += 1
+			if compare == 0 {
+				valid = next()
+			}
+		} else {
+			key = string(it.Key())
+			value = helpers.CloneBytes(it.Value())
+			valid = next()
+		}
+
+		if value == nil {
+			continue
+		}
+
+		if !callback(key, value) {
+			break
+		}
+	}
+
+	return it.Error()
+}
+
+func (tx *StoreDBLevelDBTransaction) batch() *leveldb.Batch {
+
+	batch := new(leveldb.Batch)
+	tx.local.Range(func(key string, data *StoreDBLevelDBTransactionData) bool {
+		if data.operation == "del" {
+			batch.Delete([]byte(key))
+		} else if data.operation == "put" {
+			batch.Put([]byte(key), data.value)
+		}
+		return true
+	})
+	return batch
+}
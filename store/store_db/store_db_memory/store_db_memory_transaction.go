@@ -78,3 +78,34 @@ func (tx *StoreDBMemoryTransaction) writeTx() error {
 
 	return nil
 }
+
+func (tx *StoreDBMemoryTransaction) Iterate(prefix, seek string, reverse bool, callback func(key string, value []byte) bool) error {
+
+	keys := make([]string, 0)
+	for key := range tx.store {
+		keys = append(keys, key)
+	}
+	tx.local.Range(func(key string, data *StoreDBMemoryTransactionData) bool {
+		if data.operation == "put" && tx.store[key] == nil {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	for _, key := range store_db_interface.SortKeys(keys, prefix, seek, reverse) {
+
+		value := tx.store[key]
+		if data, ok := tx.local.Load(key); ok && data.operation != "get" {
+			value = data.value
+		}
+		if value == nil {
+			continue
+		}
+
+		if !callback(key, helpers.CloneBytes(value)) {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !windows && !wasm
+// +build !windows,!wasm
+
+package store
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func lockFile(file *os.File) error {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if err == syscall.EWOULDBLOCK {
+			return errors.New("The store is already opened by another process")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"errors"
+	"golang.org/x/sys/windows"
+	"os"
+)
+
+func lockFile(file *os.File) error {
+	if err := windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &windows.Overlapped{}); err != nil {
+		if err == windows.ERROR_LOCK_VIOLATION {
+			return errors.New("The store is already opened by another process")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"errors"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//copies all the entries of the store of type fromType into a new empty store. The source store is left untouched
+func migrateStore(name, fromType string, to *Store) (err error) {
+
+	if fromType == "" {
+		return errors.New("Invalid --store-chain-migrate argument")
+	}
+
+	empty := true
+	if err = to.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return reader.Iterate("", "", false, func(key string, value []byte) bool {
+			empty = false
+			return false
+		})
+	}); err != nil {
+		return
+	}
+	if !empty {
+		return errors.New("The store migrated into is not empty")
+	}
+
+	from, err := createStoreNow(name, fromType)
+	if err != nil {
+		return
+	}
+	defer from.close()
+
+	gui.GUI.Info("Migrating store " + name + " from " + fromType)
+
+	type entry struct {
+		key   string
+		value []byte
+	}
+
+	var last string
+	count := 0
+	for {
+
+		entries := make([]*entry, 0, config.STORE_MIGRATE_BATCH_SIZE)
+		if err = from.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			return reader.Iterate("", last, false, func(key string, value []byte) bool {
+				if key != last || last == "" {
+					entries = append(entries, &entry{key, value})
+				}
+				return len(entries) < cap(entries)
+			})
+		}); err != nil {
+			return
+		}
+
+		if len(entries) == 0 {
+			break
+		}
+
+		if err = to.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			for _, it := range entries {
+				writer.Put(it.key, it.value)
+			}
+			return nil
+		}); err != nil {
+			return
+		}
+
+		count += len(entries)
+		last = entries[len(entries)-1].key
+		gui.GUI.Info("Migrated " + strconv.Itoa(count) + " entries")
+	}
+
+	return
+}
@@ -74,7 +74,7 @@ func (testnet *Testnet) testnetCreateUnstakeTx(senderAddr *wallet_address.Wallet
 
 	txData := &txs_builder.TxBuilderCreateSimpleTx{
 		0,
-		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionData{nil, false, nil},
 		&wizard.WizardTransactionFee{0, 0, 0, true},
 		&wizard.WizardTxSimpleExtraUnstake{
 			nil,
@@ -84,6 +84,7 @@ func (testnet *Testnet) testnetCreateUnstakeTx(senderAddr *wallet_address.Wallet
 			senderAddr.AddressEncoded,
 			0,
 			config_coins.NATIVE_ASSET_FULL,
+			nil,
 		}},
 		[]*txs_builder.TxBuilderCreateSimpleTxVout{},
 	}
@@ -123,13 +124,14 @@ func (testnet *Testnet) testnetCreateTransfers(senderAddr *wallet_address.Wallet
 
 	txData := &txs_builder.TxBuilderCreateSimpleTx{
 		0,
-		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionData{nil, false, nil},
 		&wizard.WizardTransactionFee{0, 0, 0, true},
 		nil,
 		[]*txs_builder.TxBuilderCreateSimpleTxVin{{
 			senderAddr.AddressEncoded,
 			total,
 			config_coins.NATIVE_ASSET_FULL,
+			nil,
 		}},
 		vout,
 	}
@@ -151,13 +153,14 @@ func (testnet *Testnet) testnetCreateRecipientTransfers(senderAddr *wallet_addre
 
 	txData := &txs_builder.TxBuilderCreateSimpleTx{
 		0,
-		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionData{nil, false, nil},
 		&wizard.WizardTransactionFee{0, 0, 0, true},
 		nil,
 		[]*txs_builder.TxBuilderCreateSimpleTxVin{{
 			senderAddr.AddressEncoded,
 			amount,
 			config_coins.NATIVE_ASSET_FULL,
+			nil,
 		}},
 		[]*txs_builder.TxBuilderCreateSimpleTxVout{{
 			addrRecipient.AddressEncoded,
@@ -1,6 +1,7 @@
 package txs_builder
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -11,6 +12,11 @@ import (
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
 	"pandora-pay/mempool"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/store"
@@ -62,6 +68,9 @@ func (builder *TxsBuilder) getWalletAddresses(senders []string) ([]*wallet_addre
 		if sendersWalletAddress[i], err = builder.wallet.GetWalletAddressByEncodedAddress(senderAddress, true); err != nil {
 			return nil, err
 		}
+		if sendersWalletAddress[i].IsWatchOnly {
+			return nil, fmt.Errorf("Watch-only address %s can't be used for signing transactions", senderAddress)
+		}
 		if sendersWalletAddress[i].PrivateKey == nil {
 			return nil, fmt.Errorf("Can't be used for transactions as the private key is missing for sender %s", senderAddress)
 		}
@@ -70,20 +79,127 @@ func (builder *TxsBuilder) getWalletAddresses(senders []string) ([]*wallet_addre
 	return sendersWalletAddress, nil
 }
 
+//vin of senders that are not in the wallet require the public key and they are left unsigned
+//unsigned txs require only the public keys of the senders
+func (builder *TxsBuilder) getSimpleTxVin(txVin []*TxBuilderCreateSimpleTxVin, sign bool) ([]*wizard.WizardTxSimpleTransferVin, [][]byte, error) {
+
+	vin := make([]*wizard.WizardTxSimpleTransferVin, len(txVin))
+	publicKeyHashes := make([][]byte, len(txVin))
+
+	for i, v := range txVin {
+
+		if len(v.PublicKey) > 0 {
+			if len(v.PublicKey) != cryptography.PublicKeySize {
+				return nil, nil, fmt.Errorf("Vin %d public key is invalid", i)
+			}
+			publicKeyHashes[i] = cryptography.GetPublicKeyHash(v.PublicKey)
+			vin[i] = &wizard.WizardTxSimpleTransferVin{
+				nil,
+				v.Amount,
+				v.Asset,
+				v.PublicKey,
+			}
+			continue
+		}
+
+		if !sign {
+
+			walletAddress, err := builder.wallet.GetWalletAddressByEncodedAddress(v.Sender, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(walletAddress.PublicKey) != cryptography.PublicKeySize {
+				return nil, nil, fmt.Errorf("Public key is missing for sender %s", v.Sender)
+			}
+
+			publicKeyHashes[i] = walletAddress.PublicKeyHash
+			vin[i] = &wizard.WizardTxSimpleTransferVin{
+				nil,
+				v.Amount,
+				v.Asset,
+				walletAddress.PublicKey,
+			}
+			continue
+		}
+
+		sendersWalletAddresses, err := builder.getWalletAddresses([]string{v.Sender})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		publicKeyHashes[i] = sendersWalletAddresses[0].PublicKeyHash
+		vin[i] = &wizard.WizardTxSimpleTransferVin{
+			sendersWalletAddresses[0].PrivateKey.Key,
+			v.Amount,
+			v.Asset,
+			sendersWalletAddresses[0].PublicKey,
+		}
+	}
+
+	return vin, publicKeyHashes, nil
+}
+
 func (builder *TxsBuilder) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propagateTx, awaitAnswer, awaitBroadcast, validateTx bool, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
+	return builder.createSimpleTx(txData, true, propagateTx, awaitAnswer, awaitBroadcast, ctx, statusCallback)
+}
+
+//the nonce and the fee are computed, but none of the vin is signed. The tx can be signed by an offline wallet
+func (builder *TxsBuilder) CreateUnsignedSimpleTx(txData *TxBuilderCreateSimpleTx, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
+	return builder.createSimpleTx(txData, false, false, false, false, ctx, statusCallback)
+}
+
+func (builder *TxsBuilder) createSimpleTx(txData *TxBuilderCreateSimpleTx, sign, propagateTx, awaitAnswer, awaitBroadcast bool, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
 
 	if txData.Data == nil {
-		txData.Data = &wizard.WizardTransactionData{nil, false}
+		txData.Data = &wizard.WizardTransactionData{nil, false, nil}
 	}
 	if txData.Fee == nil {
 		txData.Fee = &wizard.WizardTransactionFee{0, 0, 0, true}
 	}
 
-	sendersWalletAddresses, err := builder.getWalletAddresses([]string{txData.Vin[0].Sender})
+	switch txExtra := txData.Extra.(type) {
+	case *wizard.WizardTxSimpleExtraUnstake:
+		if len(txExtra.Amounts) != len(txData.Vin) {
+			return nil, errors.New("Extra amounts length is not matching vin length")
+		}
+	case *wizard.WizardTxSimpleExtraStake:
+		if len(txExtra.Amounts) != len(txData.Vin) {
+			return nil, errors.New("Extra amounts length is not matching vin length")
+		}
+	case *wizard.WizardTxSimpleExtraUpdateDelegate:
+		if len(txData.Vin) != 1 {
+			return nil, errors.New("Update Delegate requires exactly one vin")
+		}
+		if len(txExtra.DelegatedStakePublicKey) != cryptography.PublicKeySize {
+			return nil, errors.New("DelegatedStakePublicKey length is invalid")
+		}
+		if txExtra.DelegatedStakeFee > config_stake.DELEGATING_STAKING_FEE_MAX_VALUE {
+			return nil, fmt.Errorf("DelegatedStakeFee should not exceed %d", config_stake.DELEGATING_STAKING_FEE_MAX_VALUE)
+		}
+	}
+
+	if len(txData.Vin) == 0 {
+		return nil, errors.New("Vin is missing")
+	}
+
+	vin, vinPublicKeyHashes, err := builder.getSimpleTxVin(txData.Vin, sign)
 	if err != nil {
 		return nil, err
 	}
 
+	//the asset keys are by default the sender public key
+	if txExtra, ok := txData.Extra.(*wizard.WizardTxSimpleExtraAssetCreate); ok {
+		if txExtra.Asset == nil {
+			return nil, errors.New("Asset is missing")
+		}
+		if len(txExtra.Asset.UpdatePublicKey) == 0 {
+			txExtra.Asset.UpdatePublicKey = vin[0].PublicKey
+		}
+		if len(txExtra.Asset.SupplyPublicKey) == 0 {
+			txExtra.Asset.SupplyPublicKey = vin[0].PublicKey
+		}
+	}
+
 	builder.lock.Lock()
 	defer builder.lock.Unlock()
 
@@ -99,29 +215,39 @@ func (builder *TxsBuilder) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propa
 		var acc *account.Account
 		var plainAcc *plain_account.PlainAccount
 
-		for i, vin := range sendersWalletAddresses {
+		//the same sender can be used by multiple vin
+		spent := make(map[string]uint64)
+
+		for i, publicKeyHash := range vinPublicKeyHashes {
 
 			if txData.Vin[i].Amount > 0 {
 
+				key := string(publicKeyHash) + string(txData.Vin[i].Asset)
+				required := spent[key]
+				if err = helpers.SafeUint64Add(&required, txData.Vin[i].Amount); err != nil {
+					return
+				}
+				spent[key] = required
+
 				if accs, err = dataStorage.AccsCollection.GetMap(txData.Vin[i].Asset); err != nil {
 					return err
 				}
 
-				if acc, err = accs.GetAccount(vin.PublicKeyHash); err != nil {
+				if acc, err = accs.GetAccount(publicKeyHash); err != nil {
 					return
 				}
 
 				if acc == nil {
-					return errors.New("Account doesn't exist")
+					return fmt.Errorf("Account doesn't exist for vin %d", i)
 				}
 
-				if acc.Balance < txData.Vin[i].Amount {
-					return errors.New("Not enought funds")
+				if acc.Balance < required {
+					return fmt.Errorf("Not enought funds for vin %d", i)
 				}
 
 			}
 
-			if plainAcc, err = dataStorage.PlainAccs.GetPlainAccount(sendersWalletAddresses[i].PublicKeyHash); err != nil {
+			if plainAcc, err = dataStorage.PlainAccs.GetPlainAccount(publicKeyHash); err != nil {
 				return
 			}
 
@@ -137,6 +263,24 @@ func (builder *TxsBuilder) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propa
 				if plainAcc.StakeAvailable < txExtra.Amounts[i] {
 					return errors.New("You don't have enough staked coins")
 				}
+			case *wizard.WizardTxSimpleExtraStake:
+
+				required := txExtra.Amounts[i]
+				if bytes.Equal(txData.Vin[i].Asset, config_coins.NATIVE_ASSET_FULL) {
+					if err = helpers.SafeUint64Add(&required, txData.Vin[i].Amount); err != nil {
+						return
+					}
+				}
+
+				if accs, err = dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL); err != nil {
+					return
+				}
+				if acc, err = accs.GetAccount(publicKeyHash); err != nil {
+					return
+				}
+				if acc == nil || acc.Balance < required {
+					return errors.New("You don't have enough coins to stake")
+				}
 			}
 		}
 
@@ -147,18 +291,9 @@ func (builder *TxsBuilder) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propa
 
 	statusCallback("Balances checked")
 
-	txData.Nonce = builder.getNonce(txData.Nonce, sendersWalletAddresses[0].PublicKeyHash, nonce)
+	txData.Nonce = builder.getNonce(txData.Nonce, vinPublicKeyHashes[0], nonce)
 	statusCallback("Getting Nonce from Mempool")
 
-	vin := make([]*wizard.WizardTxSimpleTransferVin, len(txData.Vin))
-	for i, v := range txData.Vin {
-		vin[i] = &wizard.WizardTxSimpleTransferVin{
-			sendersWalletAddresses[i].PrivateKey.Key,
-			v.Amount,
-			v.Asset,
-		}
-	}
-
 	vout := make([]*wizard.WizardTxSimpleTransferVout, len(txData.Vout))
 	for i, v := range txData.Vout {
 		var addr *addresses.Address
@@ -185,7 +320,75 @@ func (builder *TxsBuilder) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propa
 	statusCallback("Transaction Created")
 
 	if propagateTx {
-		if err = builder.mempool.AddTxToMempool(tx, chainHeight, true, awaitAnswer, awaitBroadcast, advanced_connection_types.UUID_ALL, ctx); err != nil {
+		if err = builder.propagateSimpleTx(tx, chainHeight, awaitAnswer, awaitBroadcast, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+func (builder *TxsBuilder) propagateSimpleTx(tx *transaction.Transaction, chainHeight uint64, awaitAnswer, awaitBroadcast bool, ctx context.Context) error {
+
+	missing, err := wizard.GetSimpleTxMissingSignatures(tx)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("Transaction can not be propagated as %d signatures are missing", len(missing))
+	}
+
+	return builder.mempool.AddTxToMempool(tx, chainHeight, true, awaitAnswer, awaitBroadcast, advanced_connection_types.UUID_ALL, ctx)
+}
+
+//signs the vin that are owned by the wallet
+func (builder *TxsBuilder) SignSimpleTx(tx *transaction.Transaction, propagateTx, awaitAnswer, awaitBroadcast bool, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
+
+	missing, err := wizard.GetSimpleTxMissingSignatures(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	base := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+
+	keys := make([][]byte, 0)
+	for _, i := range missing {
+		walletAddress := builder.wallet.GetWalletAddressByPublicKeyHash(cryptography.GetPublicKeyHash(base.Vin[i].PublicKey), true)
+		if walletAddress != nil && !walletAddress.IsWatchOnly && walletAddress.PrivateKey != nil {
+			keys = append(keys, walletAddress.PrivateKey.Key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("The wallet doesn't own any of the unsigned vin")
+	}
+
+	if tx, _, err = wizard.SignSimpleTx(tx, keys, statusCallback); err != nil {
+		return nil, err
+	}
+
+	if propagateTx {
+		if err = builder.propagateSimpleTx(tx, 0, awaitAnswer, awaitBroadcast, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+func (builder *TxsBuilder) BroadcastSimpleTx(tx *transaction.Transaction, awaitAnswer, awaitBroadcast bool, ctx context.Context) error {
+	return builder.propagateSimpleTx(tx, 0, awaitAnswer, awaitBroadcast, ctx)
+}
+
+func (builder *TxsBuilder) CombineSimpleTxs(txs []*transaction.Transaction, propagateTx, awaitAnswer, awaitBroadcast bool, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
+
+	tx, err := wizard.CombineSimpleTxs(txs, statusCallback)
+	if err != nil {
+		return nil, err
+	}
+
+	if propagateTx {
+		if err = builder.propagateSimpleTx(tx, 0, awaitAnswer, awaitBroadcast, ctx); err != nil {
 			return nil, err
 		}
 	}
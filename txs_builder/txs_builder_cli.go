@@ -1,12 +1,20 @@
 package txs_builder
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"pandora-pay/addresses"
 	"pandora-pay/blockchain/data_storage/assets"
 	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
 	"pandora-pay/gui"
+	"pandora-pay/helpers"
 	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 	"pandora-pay/txs_builder/wizard"
@@ -24,6 +32,11 @@ func (builder *TxsBuilder) readData() (out *wizard.WizardTransactionData) {
 	if len(str) > 0 {
 		data.Data = []byte(str)
 		data.Encrypt = gui.GUI.OutputReadBool("Encrypt message (data)? y/n. Leave empty for no", true, false)
+		if data.Encrypt {
+			data.PublicKeyToEncrypt = gui.GUI.OutputReadBytes("Public Key of the receiver to encrypt the message", func(input []byte) bool {
+				return len(input) == cryptography.PublicKeySize
+			})
+		}
 	}
 
 	return data
@@ -132,6 +145,449 @@ func (builder *TxsBuilder) readAsset(text string, allowEmptyAsset bool) []byte {
 	return assetId
 }
 
-func (builder *TxsBuilder) initCLI() {
+func (builder *TxsBuilder) cliCreateSimpleTx(senderAddress string, extra wizard.WizardTxSimpleExtra, ctx context.Context) (tx *transaction.Transaction, err error) {
+
+	data := builder.readData()
+	fee := builder.readFee(config_coins.NATIVE_ASSET_FULL)
+	propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+	txData := &TxBuilderCreateSimpleTx{
+		0,
+		data,
+		fee,
+		extra,
+		[]*TxBuilderCreateSimpleTxVin{{
+			senderAddress,
+			0,
+			config_coins.NATIVE_ASSET_FULL,
+			nil,
+		}},
+		[]*TxBuilderCreateSimpleTxVout{},
+	}
+
+	if tx, err = builder.CreateSimpleTx(txData, propagate, true, true, false, ctx, func(status string) {
+		gui.GUI.OutputWrite(status)
+	}); err != nil {
+		return
+	}
+
+	gui.GUI.OutputWrite("Tx created: " + base64.StdEncoding.EncodeToString(tx.Bloom.Hash))
+	return
+}
+
+func (builder *TxsBuilder) cliSimpleStake(cmd string, ctx context.Context) (err error) {
+
+	builder.showWarningIfNotSyncCLI()
+
+	_, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address to Stake", ctx)
+	if err != nil {
+		return
+	}
+
+	amount, err := builder.readAmount(config_coins.NATIVE_ASSET_FULL, "Amount to Stake")
+	if err != nil {
+		return
+	}
+
+	_, err = builder.cliCreateSimpleTx(senderAddress, &wizard.WizardTxSimpleExtraStake{Amounts: []uint64{amount}}, ctx)
+	return
+}
+
+func (builder *TxsBuilder) cliSimpleUpdateDelegate(cmd string, ctx context.Context) (err error) {
+
+	builder.showWarningIfNotSyncCLI()
+
+	walletAddress, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address to Update Delegate", ctx)
+	if err != nil {
+		return
+	}
+
+	delegatedStakePublicKey := gui.GUI.OutputReadBytes("Delegated Stake Public Key. Leave empty to use the wallet shared staked key", func(input []byte) bool {
+		return len(input) == 0 || len(input) == cryptography.PublicKeySize
+	})
+	if len(delegatedStakePublicKey) == 0 {
+		if walletAddress.SharedStaked == nil {
+			return errors.New("Address has no shared staked key")
+		}
+		delegatedStakePublicKey = walletAddress.SharedStaked.PublicKey
+	}
+
+	delegatedStakeFee := gui.GUI.OutputReadUint64(fmt.Sprintf("Delegated Stake Fee (max %d)", config_stake.DELEGATING_STAKING_FEE_MAX_VALUE), false, 0, func(value uint64) bool {
+		return value <= config_stake.DELEGATING_STAKING_FEE_MAX_VALUE
+	})
+
+	_, err = builder.cliCreateSimpleTx(senderAddress, &wizard.WizardTxSimpleExtraUpdateDelegate{DelegatedStakePublicKey: delegatedStakePublicKey, DelegatedStakeFee: delegatedStakeFee}, ctx)
+	return
+}
+
+func (builder *TxsBuilder) cliAssetCreate(cmd string, ctx context.Context) (err error) {
+
+	builder.showWarningIfNotSyncCLI()
+
+	_, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address to Create Asset", ctx)
+	if err != nil {
+		return
+	}
+
+	ast := &asset.Asset{}
+	str := gui.GUI.OutputReadString("Asset JSON (one line)")
+	if err = json.Unmarshal([]byte(str), ast); err != nil {
+		return
+	}
+
+	tx, err := builder.cliCreateSimpleTx(senderAddress, &wizard.WizardTxSimpleExtraAssetCreate{Asset: ast}, ctx)
+	if err != nil {
+		return
+	}
+
+	gui.GUI.OutputWrite("Asset Id: " + base64.StdEncoding.EncodeToString(asset.GenerateAssetId(tx.Bloom.Hash)))
+	return
+}
+
+func (builder *TxsBuilder) cliAssetSupply(increase bool) func(string, context.Context) error {
+	return func(cmd string, ctx context.Context) (err error) {
+
+		builder.showWarningIfNotSyncCLI()
+
+		_, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address of the Asset Supply Key", ctx)
+		if err != nil {
+			return
+		}
+
+		assetId := builder.readAsset("Asset", false)
+
+		value, err := builder.readAmount(assetId, "Amount")
+		if err != nil {
+			return
+		}
+
+		var extra wizard.WizardTxSimpleExtra
+		if increase {
+			extra = &wizard.WizardTxSimpleExtraAssetSupplyIncrease{AssetId: assetId, Value: value}
+		} else {
+			extra = &wizard.WizardTxSimpleExtraAssetSupplyDecrease{AssetId: assetId, Value: value}
+		}
+
+		_, err = builder.cliCreateSimpleTx(senderAddress, extra, ctx)
+		return
+	}
+}
+
+func (builder *TxsBuilder) cliAssetUpdate(cmd string, ctx context.Context) (err error) {
+
+	builder.showWarningIfNotSyncCLI()
+
+	_, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address of the Asset Update Key", ctx)
+	if err != nil {
+		return
+	}
+
+	assetId := builder.readAsset("Asset", false)
+
+	var ast *asset.Asset
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		ast, err = assets.NewAssets(reader).GetAsset(assetId)
+		return
+	}); err != nil {
+		return
+	}
+	if ast == nil {
+		return errors.New("Asset was not found")
+	}
+
+	description := gui.GUI.OutputReadString("New Description. Leave empty to keep it")
+	if len(description) == 0 {
+		description = ast.Description
+	}
+
+	readKey := func(text string) []byte {
+		return gui.GUI.OutputReadBytes(text, func(input []byte) bool {
+			return len(input) == 0 || len(input) == cryptography.PublicKeySize
+		})
+	}
+
+	updatePublicKey := readKey("New Update Public Key. Leave empty to keep it")
+	supplyPublicKey := readKey("New Supply Public Key. Leave empty to keep it")
+
+	_, err = builder.cliCreateSimpleTx(senderAddress, &wizard.WizardTxSimpleExtraAssetUpdate{
+		AssetId:         assetId,
+		Description:     description,
+		Data:            ast.Data,
+		UpdatePublicKey: updatePublicKey,
+		SupplyPublicKey: supplyPublicKey,
+	}, ctx)
+	return
+}
+
+func (builder *TxsBuilder) cliAssetPause(cmd string, ctx context.Context) (err error) {
+
+	builder.showWarningIfNotSyncCLI()
+
+	_, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address of the Asset Update Key", ctx)
+	if err != nil {
+		return
+	}
+
+	assetId := builder.readAsset("Asset", false)
+	paused := gui.GUI.OutputReadBool("Pause? y/n. Leave empty for yes", true, true)
+
+	_, err = builder.cliCreateSimpleTx(senderAddress, &wizard.WizardTxSimpleExtraAssetPause{AssetId: assetId, Paused: paused}, ctx)
+	return
+}
+
+func (builder *TxsBuilder) cliAssetFreeze(cmd string, ctx context.Context) (err error) {
+
+	builder.showWarningIfNotSyncCLI()
+
+	_, senderAddress, _, err := builder.wallet.CliSelectAddress("Select Address of the Asset Supply Key", ctx)
+	if err != nil {
+		return
+	}
+
+	assetId := builder.readAsset("Asset", false)
+	if !gui.GUI.OutputReadBool("The supply will be frozen forever. Continue? y/n", false, false) {
+		return
+	}
+
+	_, err = builder.cliCreateSimpleTx(senderAddress, &wizard.WizardTxSimpleExtraAssetFreeze{AssetId: assetId}, ctx)
+	return
+}
+
+func (builder *TxsBuilder) cliReadTx(text string) (tx *transaction.Transaction, err error) {
+	tx = &transaction.Transaction{}
+	if err = tx.Deserialize(helpers.NewBufferReader(gui.GUI.OutputReadBytes(text, nil))); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//partially signed txs are exported to be signed by the other wallets
+func (builder *TxsBuilder) cliOutputPartialTx(tx *transaction.Transaction, ctx context.Context) (err error) {
 
+	missing, err := wizard.GetSimpleTxMissingSignatures(tx)
+	if err != nil {
+		return
+	}
+
+	if len(missing) > 0 {
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx requires %d more signatures for vin %v", len(missing), missing))
+		gui.GUI.OutputWrite("Tx: " + base64.StdEncoding.EncodeToString(tx.Bloom.Serialized))
+		return
+	}
+
+	gui.GUI.OutputWrite("Tx is fully signed: " + base64.StdEncoding.EncodeToString(tx.Bloom.Serialized))
+	if gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true) {
+		if err = builder.propagateSimpleTx(tx, 0, true, true, ctx); err != nil {
+			return
+		}
+		gui.GUI.OutputWrite("Tx propagated: " + base64.StdEncoding.EncodeToString(tx.Bloom.Hash))
+	}
+	return
+}
+
+//unsigned txs are created for an offline wallet and none of the vin is signed
+func (builder *TxsBuilder) cliSimpleTransfer(unsigned bool) func(string, context.Context) error {
+	return func(cmd string, ctx context.Context) (err error) {
+
+		builder.showWarningIfNotSyncCLI()
+
+		assetId := builder.readAsset("Asset. Leave empty for Native Asset", true)
+
+		sendersCount := gui.GUI.OutputReadInt("Number of senders", false, 0, func(value int) bool {
+			return value > 0 && value <= 255
+		})
+
+		txData := &TxBuilderCreateSimpleTx{
+			Vin:  make([]*TxBuilderCreateSimpleTxVin, sendersCount),
+			Vout: make([]*TxBuilderCreateSimpleTxVout, 0),
+		}
+
+		partial := false
+		total := uint64(0)
+		for i := range txData.Vin {
+
+			vin := &TxBuilderCreateSimpleTxVin{Asset: assetId}
+
+			vin.PublicKey = gui.GUI.OutputReadBytes(fmt.Sprintf("Sender %d Public Key. Leave empty to select an address from the wallet", i+1), func(input []byte) bool {
+				return len(input) == 0 || len(input) == cryptography.PublicKeySize
+			})
+
+			if len(vin.PublicKey) > 0 {
+				partial = true
+			} else if _, vin.Sender, _, err = builder.wallet.CliSelectAddress(fmt.Sprintf("Select Sender %d", i+1), ctx); err != nil {
+				return
+			}
+
+			if vin.Amount, err = builder.readAmount(assetId, fmt.Sprintf("Sender %d Amount", i+1)); err != nil {
+				return
+			}
+			if err = helpers.SafeUint64Add(&total, vin.Amount); err != nil {
+				return
+			}
+
+			txData.Vin[i] = vin
+		}
+
+		for total > 0 {
+
+			var address *addresses.Address
+			if address, err = builder.readAddress(fmt.Sprintf("Receiver Address. Remaining %d", total), false); err != nil {
+				return
+			}
+
+			vout := &TxBuilderCreateSimpleTxVout{address.EncodeAddr(), 0, assetId}
+			if vout.Amount, err = builder.readAmount(assetId, "Receiver Amount"); err != nil {
+				return
+			}
+			if vout.Amount > total {
+				return errors.New("Receivers amount exceeds the senders amount")
+			}
+
+			total -= vout.Amount
+			txData.Vout = append(txData.Vout, vout)
+		}
+
+		txData.Data = builder.readData()
+		txData.Fee = builder.readFee(config_coins.NATIVE_ASSET_FULL)
+
+		statusCallback := func(status string) {
+			gui.GUI.OutputWrite(status)
+		}
+
+		if unsigned {
+
+			var tx *transaction.Transaction
+			if tx, err = builder.CreateUnsignedSimpleTx(txData, ctx, statusCallback); err != nil {
+				return
+			}
+
+			gui.GUI.OutputWrite("Unsigned Tx: " + base64.StdEncoding.EncodeToString(wizard.SerializeUnsignedTx(tx)))
+			return
+		}
+
+		propagate := false
+		if !partial {
+			propagate = gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+		}
+
+		tx, err := builder.CreateSimpleTx(txData, propagate, true, true, false, ctx, statusCallback)
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Tx created: " + base64.StdEncoding.EncodeToString(tx.Bloom.Hash))
+		if partial {
+			return builder.cliOutputPartialTx(tx, ctx)
+		}
+		return
+	}
+}
+
+func (builder *TxsBuilder) cliSignSimpleTx(cmd string, ctx context.Context) (err error) {
+
+	tx, err := builder.cliReadTx("Partially signed Tx")
+	if err != nil {
+		return
+	}
+
+	if tx, err = builder.SignSimpleTx(tx, false, false, false, ctx, func(status string) {
+		gui.GUI.OutputWrite(status)
+	}); err != nil {
+		return
+	}
+
+	return builder.cliOutputPartialTx(tx, ctx)
+}
+
+//the unsigned tx is reviewed and signed by the offline wallet
+func (builder *TxsBuilder) cliSignUnsignedSimpleTx(cmd string, ctx context.Context) (err error) {
+
+	statusCallback := func(status string) {
+		gui.GUI.OutputWrite(status)
+	}
+
+	tx, err := wizard.DeserializeUnsignedTx(gui.GUI.OutputReadBytes("Unsigned Tx", nil), statusCallback)
+	if err != nil {
+		return
+	}
+
+	txJson, err := json.Marshal(tx)
+	if err != nil {
+		return
+	}
+	gui.GUI.OutputWrite(string(txJson))
+
+	if !gui.GUI.OutputReadBool("Sign the transaction? y/n", false, false) {
+		return
+	}
+
+	if tx, err = builder.SignSimpleTx(tx, false, false, false, ctx, statusCallback); err != nil {
+		return
+	}
+
+	missing, err := wizard.GetSimpleTxMissingSignatures(tx)
+	if err != nil {
+		return
+	}
+	if len(missing) > 0 {
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx requires %d more signatures for vin %v", len(missing), missing))
+	}
+
+	gui.GUI.OutputWrite("Signed Tx: " + base64.StdEncoding.EncodeToString(tx.Bloom.Serialized))
+	return
+}
+
+func (builder *TxsBuilder) cliBroadcastSimpleTx(cmd string, ctx context.Context) (err error) {
+
+	tx, err := builder.cliReadTx("Signed Tx")
+	if err != nil {
+		return
+	}
+
+	if err = builder.BroadcastSimpleTx(tx, true, true, ctx); err != nil {
+		return
+	}
+
+	gui.GUI.OutputWrite("Tx propagated: " + base64.StdEncoding.EncodeToString(tx.Bloom.Hash))
+	return
+}
+
+func (builder *TxsBuilder) cliCombineSimpleTxs(cmd string, ctx context.Context) (err error) {
+
+	count := gui.GUI.OutputReadInt("Number of partially signed Txs", false, 0, func(value int) bool {
+		return value > 0
+	})
+
+	txs := make([]*transaction.Transaction, count)
+	for i := range txs {
+		if txs[i], err = builder.cliReadTx(fmt.Sprintf("Partially signed Tx %d", i+1)); err != nil {
+			return
+		}
+	}
+
+	tx, err := builder.CombineSimpleTxs(txs, false, false, false, ctx, func(status string) {
+		gui.GUI.OutputWrite(status)
+	})
+	if err != nil {
+		return
+	}
+
+	return builder.cliOutputPartialTx(tx, ctx)
+}
+
+func (builder *TxsBuilder) initCLI() {
+	gui.GUI.CommandDefineCallback("Simple Transfer", builder.cliSimpleTransfer(false), true)
+	gui.GUI.CommandDefineCallback("Sign Simple Tx", builder.cliSignSimpleTx, true)
+	gui.GUI.CommandDefineCallback("Combine Simple Txs", builder.cliCombineSimpleTxs, true)
+	gui.GUI.CommandDefineCallback("Create Unsigned Simple Transfer", builder.cliSimpleTransfer(true), true)
+	gui.GUI.CommandDefineCallback("Sign Unsigned Simple Tx", builder.cliSignUnsignedSimpleTx, true)
+	gui.GUI.CommandDefineCallback("Broadcast Simple Tx", builder.cliBroadcastSimpleTx, true)
+	gui.GUI.CommandDefineCallback("Simple Stake", builder.cliSimpleStake, true)
+	gui.GUI.CommandDefineCallback("Simple Update Delegate", builder.cliSimpleUpdateDelegate, true)
+	gui.GUI.CommandDefineCallback("Asset Create", builder.cliAssetCreate, true)
+	gui.GUI.CommandDefineCallback("Asset Supply Increase", builder.cliAssetSupply(true), true)
+	gui.GUI.CommandDefineCallback("Asset Supply Decrease", builder.cliAssetSupply(false), true)
+	gui.GUI.CommandDefineCallback("Asset Update", builder.cliAssetUpdate, true)
+	gui.GUI.CommandDefineCallback("Asset Pause", builder.cliAssetPause, true)
+	gui.GUI.CommandDefineCallback("Asset Freeze", builder.cliAssetFreeze, true)
 }
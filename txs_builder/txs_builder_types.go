@@ -3,9 +3,10 @@ package txs_builder
 import "pandora-pay/txs_builder/wizard"
 
 type TxBuilderCreateSimpleTxVin struct {
-	Sender string `json:"sender" msgpack:"sender"`
-	Amount uint64 `json:"amount" msgpack:"amount"`
-	Asset  []byte `json:"asset" msgpack:"asset"`
+	Sender    string `json:"sender" msgpack:"sender"`
+	Amount    uint64 `json:"amount" msgpack:"amount"`
+	Asset     []byte `json:"asset" msgpack:"asset"`
+	PublicKey []byte `json:"publicKey,omitempty" msgpack:"publicKey,omitempty"` //sender not included in the wallet. The vin will be signed later
 }
 
 type TxBuilderCreateSimpleTxVout struct {
@@ -18,7 +19,7 @@ type TxBuilderCreateSimpleTx struct {
 	Nonce uint64                         `json:"nonce" msgpack:"nonce"`
 	Data  *wizard.WizardTransactionData  `json:"data" msgpack:"data"`
 	Fee   *wizard.WizardTransactionFee   `json:"fee" msgpack:"fee"`
-	Extra wizard.WizardTxSimpleExtra     `json:"extra" msgpack:"sender"`
+	Extra wizard.WizardTxSimpleExtra     `json:"extra" msgpack:"extra"`
 	Vin   []*TxBuilderCreateSimpleTxVin  `json:"vin" msgpack:"vin"`
 	Vout  []*TxBuilderCreateSimpleTxVout `json:"vout" msgpack:"vout"`
 }
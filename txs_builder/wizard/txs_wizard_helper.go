@@ -1,7 +1,10 @@
 package wizard
 
 import (
+	"encoding/json"
+	"errors"
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/config/config_fees"
 	"pandora-pay/helpers"
@@ -59,3 +62,43 @@ func bloomAllTx(tx *transaction.Transaction, statusCallback func(string)) (err e
 
 	return
 }
+
+//extra received as a generic object (json or msgpack) is converted into the wizard extra of the script
+func ConvertSimpleTxExtra(txScript transaction_simple.ScriptType, extra any) (WizardTxSimpleExtra, error) {
+
+	var payloadExtra WizardTxSimpleExtra
+	switch txScript {
+	case transaction_simple.SCRIPT_TRANSFER:
+		return nil, nil
+	case transaction_simple.SCRIPT_UNSTAKE:
+		payloadExtra = &WizardTxSimpleExtraUnstake{}
+	case transaction_simple.SCRIPT_STAKE:
+		payloadExtra = &WizardTxSimpleExtraStake{}
+	case transaction_simple.SCRIPT_UPDATE_DELEGATE:
+		payloadExtra = &WizardTxSimpleExtraUpdateDelegate{}
+	case transaction_simple.SCRIPT_ASSET_CREATE:
+		payloadExtra = &WizardTxSimpleExtraAssetCreate{}
+	case transaction_simple.SCRIPT_ASSET_SUPPLY_INCREASE:
+		payloadExtra = &WizardTxSimpleExtraAssetSupplyIncrease{}
+	case transaction_simple.SCRIPT_ASSET_SUPPLY_DECREASE:
+		payloadExtra = &WizardTxSimpleExtraAssetSupplyDecrease{}
+	case transaction_simple.SCRIPT_ASSET_UPDATE:
+		payloadExtra = &WizardTxSimpleExtraAssetUpdate{}
+	case transaction_simple.SCRIPT_ASSET_PAUSE:
+		payloadExtra = &WizardTxSimpleExtraAssetPause{}
+	case transaction_simple.SCRIPT_ASSET_FREEZE:
+		payloadExtra = &WizardTxSimpleExtraAssetFreeze{}
+	default:
+		return nil, errors.New("Invalid PayloadScriptType")
+	}
+
+	data, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, payloadExtra); err != nil {
+		return nil, err
+	}
+
+	return payloadExtra, nil
+}
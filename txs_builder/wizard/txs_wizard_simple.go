@@ -1,15 +1,19 @@
 package wizard
 
 import (
+	"fmt"
 	"golang.org/x/exp/slices"
 	"math"
 	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/pending_stakes_list/pending_stakes"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/dpos"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config/config_coins"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
 )
@@ -33,6 +37,64 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 		}
 		txScript = transaction_simple.SCRIPT_UNSTAKE
 		spaceExtra += len(txExtra.Amounts) * len(helpers.SerializeToBytes(&pending_stakes.PendingStakes{nil, nil, math.MaxUint64, []*pending_stakes.PendingStake{{nil, helpers.RandomBytes(cryptography.PublicKeyHashSize), txExtra.Amounts[0], true}}}))
+	case *WizardTxSimpleExtraStake:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraStake{
+			Amounts: slices.Clone(txExtra.Amounts),
+		}
+		txScript = transaction_simple.SCRIPT_STAKE
+		spaceExtra += len(txExtra.Amounts) * len(helpers.SerializeToBytes(&pending_stakes.PendingStakes{Height: math.MaxUint64, Pending: []*pending_stakes.PendingStake{{PublicKeyHash: helpers.RandomBytes(cryptography.PublicKeyHashSize), PendingAmount: txExtra.Amounts[0], PendingType: true}}}))
+	case *WizardTxSimpleExtraUpdateDelegate:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraUpdateDelegate{
+			DelegatedStakePublicKey: txExtra.DelegatedStakePublicKey,
+			DelegatedStakeFee:       txExtra.DelegatedStakeFee,
+		}
+		txScript = transaction_simple.SCRIPT_UPDATE_DELEGATE
+		spaceExtra += len(helpers.SerializeToBytes(&dpos.DelegatedStake{Version: dpos.STAKING, DelegatedStakeNonce: math.MaxUint64, DelegatedStakePublicKey: txExtra.DelegatedStakePublicKey, DelegatedStakeFee: txExtra.DelegatedStakeFee}))
+	case *WizardTxSimpleExtraAssetCreate:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraAssetCreate{
+			Asset: txExtra.Asset,
+		}
+		txScript = transaction_simple.SCRIPT_ASSET_CREATE
+		spaceExtra += len(helpers.SerializeToBytes(txExtra.Asset)) + config_coins.ASSET_LENGTH
+	case *WizardTxSimpleExtraAssetSupplyIncrease:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraAssetSupplyIncrease{
+			AssetId: txExtra.AssetId,
+			Value:   txExtra.Value,
+		}
+		txScript = transaction_simple.SCRIPT_ASSET_SUPPLY_INCREASE
+		//the asset supply grows by at most the length of Value and the supply account could be new
+		spaceExtra += helpers.BytesLengthSerialized(txExtra.Value) + len(helpers.SerializeToBytes(&account.Account{Balance: txExtra.Value})) + cryptography.PublicKeyHashSize
+	case *WizardTxSimpleExtraAssetSupplyDecrease:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraAssetSupplyDecrease{
+			AssetId: txExtra.AssetId,
+			Value:   txExtra.Value,
+		}
+		txScript = transaction_simple.SCRIPT_ASSET_SUPPLY_DECREASE
+		//the asset supply and the account balance only decrease, so neither of them grows
+	case *WizardTxSimpleExtraAssetUpdate:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraAssetUpdate{
+			AssetId:         txExtra.AssetId,
+			Description:     txExtra.Description,
+			Data:            txExtra.Data,
+			UpdatePublicKey: txExtra.UpdatePublicKey,
+			SupplyPublicKey: txExtra.SupplyPublicKey,
+		}
+		txScript = transaction_simple.SCRIPT_ASSET_UPDATE
+		//the public keys are replaced by keys of the same length
+		spaceExtra += helpers.BytesLengthSerialized(uint64(len(txExtra.Description))) + len(txExtra.Description) + helpers.BytesLengthSerialized(uint64(len(txExtra.Data))) + len(txExtra.Data)
+	case *WizardTxSimpleExtraAssetPause:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraAssetPause{
+			AssetId: txExtra.AssetId,
+			Paused:  txExtra.Paused,
+		}
+		txScript = transaction_simple.SCRIPT_ASSET_PAUSE
+		//the paused flag is always serialized for assets that CanPause, so the asset keeps its size
+	case *WizardTxSimpleExtraAssetFreeze:
+		extraFinal = &transaction_simple_extra.TransactionSimpleExtraAssetFreeze{
+			AssetId: txExtra.AssetId,
+		}
+		txScript = transaction_simple.SCRIPT_ASSET_FREEZE
+		//the frozen flag is always serialized for assets that CanFreeze, so the asset keeps its size
 	}
 
 	spaceExtra += len(transfer.Vout) * 50
@@ -50,11 +112,19 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 	privateKeys := make([]*addresses.PrivateKey, len(transfer.Vin))
 
 	for i, vin := range transfer.Vin {
-		if privateKeys[i], err = addresses.NewPrivateKey(transfer.Vin[i].Key); err != nil {
-			return nil, err
+
+		publicKey := vin.PublicKey
+		if len(vin.Key) > 0 {
+			if privateKeys[i], err = addresses.NewPrivateKey(vin.Key); err != nil {
+				return nil, err
+			}
+			publicKey = privateKeys[i].GeneratePublicKey()
+		} else if len(publicKey) != cryptography.PublicKeySize {
+			return nil, fmt.Errorf("Vin %d requires a private key or a public key", i)
 		}
+
 		txBase.Vin[i] = &transaction_simple_parts.TransactionSimpleInput{
-			privateKeys[i].GeneratePublicKey(),
+			publicKey,
 			vin.Amount,
 			vin.Asset,
 			nil,
@@ -85,12 +155,24 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 	statusCallback("Transaction Fee set")
 
 	statusCallback("Transaction Signing...")
+	hashForSignature := tx.SerializeForSigning()
+	missing := 0
 	for i, vin := range txBase.Vin {
-		if vin.Signature, err = privateKeys[i].Sign(tx.SerializeForSigning()); err != nil {
+		if privateKeys[i] == nil {
+			vin.Signature = make([]byte, cryptography.SignatureSize)
+			missing++
+			continue
+		}
+		if vin.Signature, err = privateKeys[i].Sign(hashForSignature); err != nil {
 			return nil, err
 		}
 	}
-	statusCallback("Transaction Signed")
+
+	if missing > 0 {
+		statusCallback(fmt.Sprintf("Transaction Partially Signed. %d signatures are missing", missing))
+	} else {
+		statusCallback("Transaction Signed")
+	}
 
 	if err = bloomAllTx(tx, statusCallback); err != nil {
 		return
@@ -0,0 +1,168 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+)
+
+func getSimpleTxBase(tx *transaction.Transaction) (*transaction_simple.TransactionSimple, error) {
+	if tx == nil || tx.Version != transaction_type.TX_SIMPLE {
+		return nil, errors.New("Transaction is not a simple transaction")
+	}
+	return tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple), nil
+}
+
+func cloneTx(tx *transaction.Transaction) (*transaction.Transaction, error) {
+	out := &transaction.Transaction{}
+	if err := out.Deserialize(helpers.NewBufferReader(tx.SerializeManualToBytes())); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+//the tx bloom contains the serialized tx and it needs to be recomputed after signing
+func rebloomTx(tx *transaction.Transaction, statusCallback func(string)) error {
+	tx.Bloom = nil
+	return bloomAllTx(tx, statusCallback)
+}
+
+//unsigned transactions are serialized without the signatures to be signed offline
+func SerializeUnsignedTx(tx *transaction.Transaction) []byte {
+	w := helpers.NewBufferWriter()
+	tx.SerializeAdvanced(w, false)
+	return w.Bytes()
+}
+
+func DeserializeUnsignedTx(data []byte, statusCallback func(string)) (*transaction.Transaction, error) {
+
+	tx := &transaction.Transaction{}
+	if err := tx.DeserializeAdvanced(helpers.NewBufferReader(data), false); err != nil {
+		return nil, err
+	}
+
+	if _, err := getSimpleTxBase(tx); err != nil {
+		return nil, err
+	}
+
+	if err := bloomAllTx(tx, statusCallback); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+//returns the indexes of the vin that were not signed yet
+func GetSimpleTxMissingSignatures(tx *transaction.Transaction) ([]int, error) {
+
+	base, err := getSimpleTxBase(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	hashForSignature := tx.SerializeForSigning()
+
+	missing := make([]int, 0)
+	for i, vin := range base.Vin {
+		if !cryptography.VerifySignature(vin.PublicKey, hashForSignature, vin.Signature) {
+			missing = append(missing, i)
+		}
+	}
+
+	return missing, nil
+}
+
+//signs all the vin that are matching the private keys
+func SignSimpleTx(tx *transaction.Transaction, keys [][]byte, statusCallback func(string)) (tx2 *transaction.Transaction, signed int, err error) {
+
+	if tx2, err = cloneTx(tx); err != nil {
+		return
+	}
+
+	base, err := getSimpleTxBase(tx2)
+	if err != nil {
+		return
+	}
+
+	statusCallback("Transaction Signing...")
+	hashForSignature := tx2.SerializeForSigning()
+
+	for _, key := range keys {
+
+		var privateKey *addresses.PrivateKey
+		if privateKey, err = addresses.NewPrivateKey(key); err != nil {
+			return
+		}
+		publicKey := privateKey.GeneratePublicKey()
+
+		for _, vin := range base.Vin {
+			if bytes.Equal(vin.PublicKey, publicKey) {
+				if vin.Signature, err = privateKey.Sign(hashForSignature); err != nil {
+					return
+				}
+				signed++
+			}
+		}
+	}
+
+	if signed == 0 {
+		return nil, 0, errors.New("None of the keys is used by the transaction")
+	}
+	statusCallback("Transaction Signed")
+
+	if err = rebloomTx(tx2, statusCallback); err != nil {
+		return
+	}
+
+	return
+}
+
+//combines the signatures of the same transaction signed by different wallets
+func CombineSimpleTxs(txs []*transaction.Transaction, statusCallback func(string)) (tx2 *transaction.Transaction, err error) {
+
+	if len(txs) == 0 {
+		return nil, errors.New("No transactions to combine")
+	}
+
+	if tx2, err = cloneTx(txs[0]); err != nil {
+		return
+	}
+
+	base, err := getSimpleTxBase(tx2)
+	if err != nil {
+		return
+	}
+
+	hashForSignature := tx2.SerializeForSigning()
+
+	for _, tx := range txs[1:] {
+
+		var other *transaction_simple.TransactionSimple
+		if other, err = getSimpleTxBase(tx); err != nil {
+			return
+		}
+
+		if !bytes.Equal(hashForSignature, tx.SerializeForSigning()) {
+			return nil, errors.New("Transactions are different and can not be combined")
+		}
+
+		for i, vin := range base.Vin {
+			if !cryptography.VerifySignature(vin.PublicKey, hashForSignature, vin.Signature) &&
+				cryptography.VerifySignature(vin.PublicKey, hashForSignature, other.Vin[i].Signature) {
+				vin.Signature = helpers.CloneBytes(other.Vin[i].Signature)
+			}
+		}
+	}
+	statusCallback("Transaction Signatures Combined")
+
+	if err = rebloomTx(tx2, statusCallback); err != nil {
+		return
+	}
+
+	return
+}
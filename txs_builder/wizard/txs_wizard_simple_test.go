@@ -1,10 +1,18 @@
 package wizard
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"github.com/stretchr/testify/assert"
 	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
 	"testing"
 )
 
@@ -30,11 +38,13 @@ func TestWizardSimple_CreateTx(t *testing.T) {
 				vin1.Key,
 				30,
 				asset1,
+				nil,
 			},
 			{
 				vin2.Key,
 				20,
 				asset2,
+				nil,
 			},
 		},
 		[]*WizardTxSimpleTransferVout{
@@ -64,3 +74,191 @@ func TestWizardSimple_CreateTx(t *testing.T) {
 	assert.Equal(t, tx.VerifySignatureManually(), true)
 
 }
+
+func TestWizardSimple_PartialSign(t *testing.T) {
+
+	keys := make([]*addresses.PrivateKey, 3)
+	for i := range keys {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		assert.Nil(t, err)
+		keys[i], err = addresses.NewPrivateKey(key)
+		assert.Nil(t, err)
+	}
+
+	asset := helpers.RandomBytes(config_coins.ASSET_LENGTH)
+
+	vin := make([]*WizardTxSimpleTransferVin, len(keys))
+	for i, key := range keys {
+		vin[i] = &WizardTxSimpleTransferVin{nil, 10, asset, key.GeneratePublicKey()}
+	}
+	vin[0].Key = keys[0].Key
+
+	tx, err := CreateSimpleTx(&WizardTxSimpleTransfer{
+		nil,
+		&WizardTransactionData{},
+		&WizardTransactionFee{},
+		0,
+		vin,
+		[]*WizardTxSimpleTransferVout{{helpers.RandomBytes(20), 30, asset}},
+	}, false, func(string) {})
+	assert.Nil(t, err)
+
+	missing, err := GetSimpleTxMissingSignatures(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, missing)
+
+	tx1, signed, err := SignSimpleTx(tx, [][]byte{keys[1].Key}, func(string) {})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, signed)
+
+	tx2, signed, err := SignSimpleTx(tx, [][]byte{keys[2].Key}, func(string) {})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, signed)
+
+	_, _, err = SignSimpleTx(tx, [][]byte{addresses.GenerateNewPrivateKey().Key}, func(string) {})
+	assert.NotNil(t, err)
+
+	final, err := CombineSimpleTxs([]*transaction.Transaction{tx, tx1, tx2}, func(string) {})
+	assert.Nil(t, err)
+
+	missing, err = GetSimpleTxMissingSignatures(final)
+	assert.Nil(t, err)
+	assert.Empty(t, missing)
+	assert.True(t, final.VerifySignatureManually())
+	assert.Equal(t, tx.SerializeForSigning(), final.SerializeForSigning())
+
+}
+
+func TestWizardSimple_UnsignedTx(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.Nil(t, err)
+
+	asset := helpers.RandomBytes(config_coins.ASSET_LENGTH)
+
+	tx, err := CreateSimpleTx(&WizardTxSimpleTransfer{
+		nil,
+		&WizardTransactionData{},
+		&WizardTransactionFee{},
+		5,
+		[]*WizardTxSimpleTransferVin{{nil, 10, asset, privateKey.GeneratePublicKey()}},
+		[]*WizardTxSimpleTransferVout{{helpers.RandomBytes(20), 10, asset}},
+	}, false, func(string) {})
+	assert.Nil(t, err)
+
+	unsigned := SerializeUnsignedTx(tx)
+	assert.Less(t, len(unsigned), len(tx.Bloom.Serialized))
+
+	tx2, err := DeserializeUnsignedTx(unsigned, func(string) {})
+	assert.Nil(t, err)
+	assert.Equal(t, tx.SerializeForSigning(), tx2.SerializeForSigning())
+
+	tx3, signed, err := SignSimpleTx(tx2, [][]byte{privateKey.Key}, func(string) {})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, signed)
+	assert.True(t, tx3.VerifySignatureManually())
+
+	tx4 := &transaction.Transaction{}
+	assert.Nil(t, tx4.Deserialize(helpers.NewBufferReader(tx3.Bloom.Serialized)))
+	assert.Equal(t, tx3.Bloom.Hash, tx4.Bloom.Hash)
+
+}
+
+func TestWizardSimple_AssetLifecycle(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.Nil(t, err)
+
+	publicKey := privateKey.GeneratePublicKey()
+	publicKeyHash := privateKey.GeneratePublicKeyHash()
+
+	db, err := store_db_memory.CreateStoreDBMemory("assets")
+	assert.Nil(t, err)
+
+	includeTx := func(extra WizardTxSimpleExtra, nonce uint64, vin *WizardTxSimpleTransferVin, vout []*WizardTxSimpleTransferVout) (tx *transaction.Transaction, err error) {
+
+		if tx, err = CreateSimpleTx(&WizardTxSimpleTransfer{
+			extra,
+			&WizardTransactionData{},
+			&WizardTransactionFee{},
+			nonce,
+			[]*WizardTxSimpleTransferVin{vin},
+			vout,
+		}, true, func(string) {}); err != nil {
+			return
+		}
+
+		err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+			dataStorage := data_storage.NewDataStorage(dbTx)
+			if err = tx.IncludeTransaction(1, dataStorage); err != nil {
+				return
+			}
+			return dataStorage.CommitChanges()
+		})
+		return
+	}
+
+	vinNative := &WizardTxSimpleTransferVin{privateKey.Key, 0, config_coins.NATIVE_ASSET_FULL, nil}
+
+	err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(dbTx)
+		if _, err = dataStorage.CreatePlainAccount(publicKeyHash); err != nil {
+			return
+		}
+		if _, _, err = dataStorage.CreateAccount(config_coins.NATIVE_ASSET_FULL, publicKeyHash); err != nil {
+			return
+		}
+		return dataStorage.CommitChanges()
+	})
+	assert.Nil(t, err)
+
+	tx, err := includeTx(&WizardTxSimpleExtraAssetCreate{Asset: &asset.Asset{
+		CanMint:         true,
+		CanPause:        true,
+		MaxSupply:       1000000,
+		UpdatePublicKey: publicKey,
+		SupplyPublicKey: publicKey,
+		Name:            "TEST",
+		Ticker:          "TEST",
+		Description:     "Test asset",
+	}}, 0, vinNative, nil)
+	assert.Nil(t, err)
+
+	assetId := asset.GenerateAssetId(tx.Bloom.Hash)
+
+	_, err = includeTx(&WizardTxSimpleExtraAssetSupplyIncrease{AssetId: assetId, Value: 500000}, 1, vinNative, nil)
+	assert.Nil(t, err)
+
+	_, err = includeTx(&WizardTxSimpleExtraAssetPause{AssetId: assetId, Paused: true}, 2, vinNative, nil)
+	assert.Nil(t, err)
+
+	err = db.View(func(dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(dbTx)
+
+		ast, err := dataStorage.Asts.GetAsset(assetId)
+		assert.Nil(t, err)
+		assert.NotNil(t, ast)
+		assert.Equal(t, uint64(500000), ast.Supply)
+		assert.True(t, ast.Paused)
+		assert.Nil(t, ast.Validate())
+
+		accs, err := dataStorage.AccsCollection.GetMap(assetId)
+		assert.Nil(t, err)
+		acc, err := accs.GetAccount(publicKeyHash)
+		assert.Nil(t, err)
+		assert.NotNil(t, acc)
+		assert.Equal(t, uint64(500000), acc.Balance)
+
+		return
+	})
+	assert.Nil(t, err)
+
+	//transfers of a paused asset are rejected
+	_, err = includeTx(nil, 3, &WizardTxSimpleTransferVin{privateKey.Key, 10, assetId, nil}, []*WizardTxSimpleTransferVout{{helpers.RandomBytes(cryptography.PublicKeyHashSize), 10, assetId}})
+	assert.NotNil(t, err)
+
+}
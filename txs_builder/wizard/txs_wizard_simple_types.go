@@ -1,5 +1,7 @@
 package wizard
 
+import "pandora-pay/blockchain/data_storage/assets/asset"
+
 type WizardTxSimpleExtra interface {
 }
 
@@ -8,10 +10,59 @@ type WizardTxSimpleExtraUnstake struct {
 	Amounts []uint64
 }
 
+type WizardTxSimpleExtraStake struct {
+	WizardTxSimpleExtra
+	Amounts []uint64
+}
+
+type WizardTxSimpleExtraUpdateDelegate struct {
+	WizardTxSimpleExtra
+	DelegatedStakePublicKey []byte
+	DelegatedStakeFee       uint64
+}
+
+type WizardTxSimpleExtraAssetCreate struct {
+	WizardTxSimpleExtra
+	Asset *asset.Asset
+}
+
+type WizardTxSimpleExtraAssetSupplyIncrease struct {
+	WizardTxSimpleExtra
+	AssetId []byte
+	Value   uint64
+}
+
+type WizardTxSimpleExtraAssetSupplyDecrease struct {
+	WizardTxSimpleExtra
+	AssetId []byte
+	Value   uint64
+}
+
+type WizardTxSimpleExtraAssetUpdate struct {
+	WizardTxSimpleExtra
+	AssetId         []byte
+	Description     string
+	Data            []byte
+	UpdatePublicKey []byte
+	SupplyPublicKey []byte
+}
+
+type WizardTxSimpleExtraAssetPause struct {
+	WizardTxSimpleExtra
+	AssetId []byte
+	Paused  bool
+}
+
+type WizardTxSimpleExtraAssetFreeze struct {
+	WizardTxSimpleExtra
+	AssetId []byte
+}
+
 type WizardTxSimpleTransferVin struct {
-	Key    []byte `json:"key" msgpack:"key"`
-	Amount uint64 `json:"amount" msgpack:"amount"`
-	Asset  []byte `json:"asset" msgpack:"asset"`
+	Key       []byte `json:"key" msgpack:"key"`
+	Amount    uint64 `json:"amount" msgpack:"amount"`
+	Asset     []byte `json:"asset" msgpack:"asset"`
+	PublicKey []byte `json:"publicKey,omitempty" msgpack:"publicKey,omitempty"` //used when the key is missing and the vin will be signed later
 }
 
 type WizardTxSimpleTransferVout struct {
@@ -2,7 +2,11 @@ package wizard
 
 import (
 	"errors"
+	"fmt"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/encryption"
 )
 
 type WizardTransactionFee struct {
@@ -22,8 +26,9 @@ func (fee *WizardTransactionFee) Clone() *WizardTransactionFee {
 }
 
 type WizardTransactionData struct {
-	Data    []byte `json:"data,omitempty" msgpack:"data,omitempty"`
-	Encrypt bool   `json:"encrypt,omitempty" msgpack:"encrypt,omitempty"`
+	Data               []byte `json:"data,omitempty" msgpack:"data,omitempty"`
+	Encrypt            bool   `json:"encrypt,omitempty" msgpack:"encrypt,omitempty"`
+	PublicKeyToEncrypt []byte `json:"publicKeyToEncrypt,omitempty" msgpack:"publicKeyToEncrypt,omitempty"` //public key of the receiver
 }
 
 func (data *WizardTransactionData) getDataVersion() transaction_data.TransactionDataVersion {
@@ -42,13 +47,14 @@ func (data *WizardTransactionData) getData() ([]byte, error) {
 	}
 	if !data.Encrypt {
 		return data.Data, nil
-	} else {
-		return nil, errors.New("Not supported right now")
-		//pub, err := ecdsa.DecompressPubkey(data.PublicKeyToEncrypt)
-		//if err != nil {
-		//	return nil, err
-		//}
-		//
-		//return ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(pub), data.Data, nil, nil)
 	}
+
+	if len(data.PublicKeyToEncrypt) != cryptography.PublicKeySize {
+		return nil, errors.New("Public key to encrypt the data is invalid")
+	}
+	if len(data.Data)+encryption.X25519EncryptionOverhead > config.TRANSACTIONS_MAX_DATA_LENGTH {
+		return nil, fmt.Errorf("Data is too long to be encrypted. Max %d bytes", config.TRANSACTIONS_MAX_DATA_LENGTH-encryption.X25519EncryptionOverhead)
+	}
+
+	return encryption.EncryptX25519(data.PublicKeyToEncrypt, data.Data)
 }
@@ -3,6 +3,7 @@ package wallet_address
 import (
 	"errors"
 	"pandora-pay/addresses"
+	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/derivation"
 	"pandora-pay/wallet/wallet_address/shared_staked"
 )
@@ -19,6 +20,7 @@ type WalletAddress struct {
 	PublicKeyHash  []byte                                   `json:"publicKeyHash" msgpack:"publicKeyHash"`
 	IsSharedStaked bool                                     `json:"isSharedStaked,omitempty" msgpack:"isSharedStaked,omitempty"`
 	SharedStaked   *shared_staked.WalletAddressSharedStaked `json:"sharedStaked,omitempty" msgpack:"sharedStaked,omitempty"`
+	IsWatchOnly    bool                                     `json:"isWatchOnly,omitempty" msgpack:"isWatchOnly,omitempty"`
 	AddressEncoded string                                   `json:"addressEncoded" msgpack:"addressEncoded"`
 }
 
@@ -84,7 +86,10 @@ func (addr *WalletAddress) SignMessage(message []byte) ([]byte, error) {
 }
 
 func (addr *WalletAddress) VerifySignedMessage(message, signature []byte) (bool, error) {
-	return addr.PrivateKey.Verify(message, signature), nil
+	if len(addr.PublicKey) != cryptography.PublicKeySize {
+		return false, errors.New("Public Key is missing")
+	}
+	return cryptography.VerifySignature(addr.PublicKey, message, signature), nil
 }
 
 func (addr *WalletAddress) Clone() *WalletAddress {
@@ -110,6 +115,7 @@ func (addr *WalletAddress) Clone() *WalletAddress {
 		addr.PublicKeyHash,
 		addr.IsSharedStaked,
 		sharedStaked,
+		addr.IsWatchOnly,
 		addr.AddressEncoded,
 	}
 }
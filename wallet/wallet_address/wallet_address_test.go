@@ -0,0 +1,77 @@
+package wallet_address
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"testing"
+)
+
+func TestWalletAddress_SignMessage(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.Nil(t, err)
+
+	addr := &WalletAddress{PrivateKey: privateKey, PublicKey: privateKey.GeneratePublicKey()}
+	watchOnly := &WalletAddress{PublicKey: privateKey.GeneratePublicKey(), IsWatchOnly: true}
+
+	message := cryptography.RandomHash()
+	signature, err := addr.SignMessage(message)
+	assert.Nil(t, err)
+
+	valid, err := addr.VerifySignedMessage(message, signature)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	valid, err = watchOnly.VerifySignedMessage(message, signature)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	valid, err = watchOnly.VerifySignedMessage(cryptography.RandomHash(), signature)
+	assert.Nil(t, err)
+	assert.False(t, valid)
+
+	_, err = watchOnly.SignMessage(message)
+	assert.NotNil(t, err)
+
+}
+
+func TestWalletAddress_VerifySignedMessageWithoutPublicKey(t *testing.T) {
+
+	watchOnly := &WalletAddress{PublicKeyHash: helpers.RandomBytes(cryptography.PublicKeyHashSize), IsWatchOnly: true}
+
+	valid, err := watchOnly.VerifySignedMessage(cryptography.RandomHash(), helpers.RandomBytes(cryptography.SignatureSize))
+	assert.NotNil(t, err)
+	assert.False(t, valid)
+
+}
+
+func TestWalletAddress_ExportWatchOnly(t *testing.T) {
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.Nil(t, err)
+
+	addr := &WalletAddress{
+		Name:           "Addr",
+		SecretKey:      helpers.RandomBytes(32),
+		PrivateKey:     privateKey,
+		PublicKey:      privateKey.GeneratePublicKey(),
+		AddressEncoded: "address",
+	}
+
+	exported := addr.ExportWatchOnly()
+	assert.Equal(t, "Addr", exported.Name)
+	assert.Equal(t, "address", exported.Address)
+	assert.Equal(t, []byte(privateKey.GeneratePublicKey()), []byte(exported.PublicKey))
+
+	exported.PublicKey[0] += 1
+	assert.NotEqual(t, addr.PublicKey, []byte(exported.PublicKey))
+
+}
@@ -0,0 +1,20 @@
+package wallet_address
+
+import (
+	"pandora-pay/helpers"
+)
+
+type WalletAddressWatchOnlyExported struct {
+	Name      string         `json:"name" msgpack:"name"`
+	Address   string         `json:"address" msgpack:"address"`
+	PublicKey helpers.Base64 `json:"publicKey,omitempty" msgpack:"publicKey,omitempty"`
+}
+
+//only the public data of the address is exported, the keys are never included
+func (addr *WalletAddress) ExportWatchOnly() *WalletAddressWatchOnlyExported {
+	return &WalletAddressWatchOnlyExported{
+		addr.Name,
+		addr.AddressEncoded,
+		helpers.CloneBytes(addr.PublicKey),
+	}
+}
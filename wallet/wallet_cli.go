@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
 	"pandora-pay/gui"
 	"pandora-pay/helpers"
 	"pandora-pay/store"
@@ -69,8 +71,10 @@ func (wallet *Wallet) CliListAddresses(cmd string, ctx context.Context) (err err
 		plainAcc      *plain_account.PlainAccount
 		assetsList    []*AddressAsset
 		publicKeyHash []byte
+		publicKey     []byte
 		name          string
 		addressString string
+		watchOnly     bool
 	}
 
 	wallet.Lock.RLock()
@@ -84,7 +88,7 @@ func (wallet *Wallet) CliListAddresses(cmd string, ctx context.Context) (err err
 	addresses := make([]*Address, len(wallet.Addresses))
 
 	for i, walletAddress := range wallet.Addresses {
-		addresses[i] = &Address{publicKeyHash: helpers.CloneBytes(walletAddress.PublicKeyHash), name: walletAddress.Name, addressString: walletAddress.GetAddress()}
+		addresses[i] = &Address{publicKeyHash: helpers.CloneBytes(walletAddress.PublicKeyHash), publicKey: helpers.CloneBytes(walletAddress.PublicKey), name: walletAddress.Name, addressString: walletAddress.GetAddress(), watchOnly: walletAddress.IsWatchOnly}
 	}
 	wallet.Lock.RUnlock()
 
@@ -141,7 +145,14 @@ func (wallet *Wallet) CliListAddresses(cmd string, ctx context.Context) (err err
 
 	for i, address := range addresses {
 
-		gui.GUI.OutputWrite(fmt.Sprintf("%d) %s :: %s", i, address.name, address.addressString))
+		if address.watchOnly {
+			gui.GUI.OutputWrite(fmt.Sprintf("%d) %s :: %s [WATCH-ONLY]", i, address.name, address.addressString))
+		} else {
+			gui.GUI.OutputWrite(fmt.Sprintf("%d) %s :: %s", i, address.name, address.addressString))
+		}
+		if len(address.publicKey) > 0 {
+			gui.GUI.OutputWrite(fmt.Sprintf("%18s: %s", "Public Key", base64.StdEncoding.EncodeToString(address.publicKey)))
+		}
 
 		if len(addresses[i].assetsList) == 0 && addresses[i].plainAcc == nil {
 			gui.GUI.OutputWrite(fmt.Sprintf("%18s: %s", "", "EMPTY"))
@@ -280,6 +291,52 @@ func (wallet *Wallet) initWalletCLI() {
 		return
 	}
 
+	cliImportWatchOnlyAddress := func(cmd string, ctx context.Context) (err error) {
+
+		addressEncoded := gui.GUI.OutputReadString("Address to watch. Leave empty to use a public key")
+
+		var publicKey []byte
+		if addressEncoded == "" {
+			publicKey = gui.GUI.OutputReadBytes("Public Key", func(input []byte) bool {
+				return len(input) == cryptography.PublicKeySize
+			})
+		}
+
+		name := gui.GUI.OutputReadString("Write Name of the watch-only address")
+
+		var adr *wallet_address.WalletAddress
+		if adr, err = wallet.ImportWatchOnlyAddress(name, addressEncoded, publicKey, true); err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Watch-only address was imported: " + adr.AddressEncoded)
+		return
+	}
+
+	cliExportWatchOnlyAddresses := func(cmd string, ctx context.Context) (err error) {
+
+		filename := gui.GUI.OutputReadFilename("Path to export", "json")
+
+		f, err := os.Create(filename)
+		if err != nil {
+			return
+		}
+
+		defer f.Close()
+
+		var marshal []byte
+		if marshal, err = json.Marshal(wallet.ExportWatchOnlyAddresses(true)); err != nil {
+			return errors.New("Error marshaling watch-only addresses")
+		}
+
+		if _, err = fmt.Fprint(f, string(marshal)); err != nil {
+			return
+		}
+
+		gui.GUI.Info("Exported successfully to: ", filename)
+		return
+	}
+
 	cliExportWalletJSON := func(cmd string, ctx context.Context) (err error) {
 
 		filename := gui.GUI.OutputReadFilename("Path to export", "pandorawallet")
@@ -538,6 +595,8 @@ func (wallet *Wallet) initWalletCLI() {
 	gui.GUI.CommandDefineCallback("Export Addresses", cliExportAddresses, wallet.Loaded)
 	gui.GUI.CommandDefineCallback("Export Address JSON", cliExportAddressJSON, wallet.Loaded)
 	gui.GUI.CommandDefineCallback("Import Address JSON", cliImportAddressJSON, wallet.Loaded)
+	gui.GUI.CommandDefineCallback("Import Watch-Only Address", cliImportWatchOnlyAddress, wallet.Loaded)
+	gui.GUI.CommandDefineCallback("Export Watch-Only Addresses", cliExportWatchOnlyAddresses, wallet.Loaded)
 	gui.GUI.CommandDefineCallback("Export Wallet JSON", cliExportWalletJSON, wallet.Loaded)
 	gui.GUI.CommandDefineCallback("Import Wallet JSON", cliImportWalletJSON, wallet.Loaded)
 	gui.GUI.CommandDefineCallback("Encrypt Wallet", cliEncryptWallet, wallet.Loaded)
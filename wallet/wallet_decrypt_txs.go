@@ -3,12 +3,17 @@ package wallet
 import (
 	"errors"
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_data"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 )
 
-
 type DecryptedTx struct {
-	Type     transaction_type.TransactionVersion `json:"type" msgpack:"type"`
+	Type        transaction_type.TransactionVersion     `json:"type" msgpack:"type"`
+	DataVersion transaction_data.TransactionDataVersion `json:"dataVersion" msgpack:"dataVersion"`
+	Data        []byte                                  `json:"data,omitempty" msgpack:"data,omitempty"`
+	Decrypted   bool                                    `json:"decrypted" msgpack:"decrypted"`
+	Error       string                                  `json:"error,omitempty" msgpack:"error,omitempty"` //why the data was not decrypted
 }
 
 func (w *Wallet) DecryptTx(tx *transaction.Transaction, publicKeyHash []byte) (*DecryptedTx, error) {
@@ -26,6 +31,32 @@ func (w *Wallet) DecryptTx(tx *transaction.Transaction, publicKeyHash []byte) (*
 
 	switch tx.Version {
 	case transaction_type.TX_SIMPLE:
+		base := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+
+		output.DataVersion = base.DataVersion
+
+		switch base.DataVersion {
+		case transaction_data.TX_DATA_PLAIN_TEXT:
+			output.Data = base.Data
+		case transaction_data.TX_DATA_ENCRYPTED:
+
+			addr := w.GetWalletAddressByPublicKeyHash(publicKeyHash, true)
+			if addr == nil {
+				return nil, errors.New("Address was not found in the wallet")
+			}
+			if addr.PrivateKey == nil {
+				return nil, errors.New("Private key is missing")
+			}
+
+			//the data is encrypted only for the receiver. A tampered data or a different receiver fails the authentication
+			data, err := addr.PrivateKey.Decrypt(base.Data)
+			if err != nil {
+				output.Error = err.Error()
+				break
+			}
+			output.Data = data
+			output.Decrypted = true
+		}
 	}
 
 	return output, nil
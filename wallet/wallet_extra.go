@@ -35,7 +35,7 @@ func (wallet *Wallet) processRefreshWallets() {
 					visited := make(map[string]bool)
 					for i := 0; i < 50; i++ {
 						addr := wallet.GetRandomAddress()
-						if visited[string(addr.PublicKey)] {
+						if addr.IsWatchOnly || visited[string(addr.PublicKey)] {
 							continue
 						}
 						visited[string(addr.PublicKeyHash)] = true
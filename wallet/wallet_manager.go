@@ -11,6 +11,7 @@ import (
 	"pandora-pay/config/globals"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/derivation"
+	"pandora-pay/helpers"
 	"pandora-pay/wallet/wallet_address"
 	"pandora-pay/wallet/wallet_address/shared_staked"
 	"strconv"
@@ -164,6 +165,96 @@ func (wallet *Wallet) AddSharedStakedAddress(addr *wallet_address.WalletAddress,
 	return
 }
 
+//watch-only addresses are used only to track the balances and the history, they can't sign nor forge
+func (wallet *Wallet) ImportWatchOnlyAddress(name, addressEncoded string, publicKey []byte, lock bool) (*wallet_address.WalletAddress, error) {
+
+	if lock {
+		wallet.Lock.Lock()
+		defer wallet.Lock.Unlock()
+	}
+
+	if !wallet.Loaded {
+		return nil, errors.New("Wallet was not loaded!")
+	}
+
+	var publicKeyHash []byte
+
+	if len(publicKey) > 0 {
+		if len(publicKey) != cryptography.PublicKeySize {
+			return nil, errors.New("Invalid Public Key length")
+		}
+		publicKeyHash = cryptography.GetPublicKeyHash(publicKey)
+	}
+
+	if addressEncoded != "" {
+		address, err := addresses.DecodeAddr(addressEncoded)
+		if err != nil {
+			return nil, err
+		}
+		if publicKeyHash != nil && !bytes.Equal(publicKeyHash, address.PublicKeyHash) {
+			return nil, errors.New("Public Key is not matching the address")
+		}
+		publicKeyHash = address.PublicKeyHash
+	}
+
+	if publicKeyHash == nil {
+		return nil, errors.New("Address or Public Key is required")
+	}
+
+	if wallet.addressesMap[string(publicKeyHash)] != nil {
+		return nil, errors.New("Address exists")
+	}
+
+	address, err := addresses.CreateAddr(publicKeyHash, nil, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "Watch-Only Address " + strconv.Itoa(wallet.CountImportedIndex)
+		wallet.CountImportedIndex += 1
+	}
+
+	addr := &wallet_address.WalletAddress{
+		Version:        wallet_address.VERSION_NORMAL,
+		Name:           name,
+		IsImported:     true,
+		PublicKey:      helpers.CloneBytes(publicKey),
+		PublicKeyHash:  publicKeyHash,
+		IsWatchOnly:    true,
+		AddressEncoded: address.EncodeAddr(),
+	}
+
+	wallet.Addresses = append(wallet.Addresses, addr)
+	wallet.addressesMap[string(addr.PublicKeyHash)] = addr
+
+	wallet.Count += 1
+
+	wallet.updateWallet()
+
+	if err = wallet.saveWallet(len(wallet.Addresses)-1, len(wallet.Addresses), -1, false); err != nil {
+		return nil, err
+	}
+	globals.MainEvents.BroadcastEvent("wallet/added", addr)
+
+	return addr.Clone(), nil
+}
+
+func (wallet *Wallet) ExportWatchOnlyAddresses(lock bool) []*wallet_address.WalletAddressWatchOnlyExported {
+
+	if lock {
+		wallet.Lock.RLock()
+		defer wallet.Lock.RUnlock()
+	}
+
+	list := make([]*wallet_address.WalletAddressWatchOnlyExported, len(wallet.Addresses))
+	for i, addr := range wallet.Addresses {
+		list[i] = addr.ExportWatchOnly()
+	}
+
+	return list
+}
+
 func (wallet *Wallet) AddAddress(addr *wallet_address.WalletAddress, lock bool, incrementSeedIndex, incrementImportedCountIndex, save bool) (err error) {
 
 	if lock {
@@ -175,6 +266,10 @@ func (wallet *Wallet) AddAddress(addr *wallet_address.WalletAddress, lock bool,
 		return errors.New("Wallet was not loaded!")
 	}
 
+	if addr.PrivateKey == nil {
+		return errors.New("Private Key is missing")
+	}
+
 	var addr1 *addresses.Address
 
 	if addr1, err = addr.PrivateKey.GenerateAddress(nil, 0, nil); err != nil {
@@ -421,6 +516,9 @@ func (wallet *Wallet) GetAddressSecretKey(index int) ([]byte, error) { //32 byte
 	if index < 0 || index > len(wallet.Addresses) {
 		return nil, errors.New("Invalid Address Index")
 	}
+	if wallet.Addresses[index].IsWatchOnly {
+		return nil, errors.New("Watch-only addresses don't have a secret key")
+	}
 	return wallet.Addresses[index].SecretKey, nil
 }
 
@@ -432,6 +530,9 @@ func (wallet *Wallet) ImportWalletAddressJSON(data []byte) (*wallet_address.Wall
 	}
 
 	if addr.PrivateKey == nil {
+		if addr.IsWatchOnly {
+			return wallet.ImportWatchOnlyAddress(addr.Name, addr.AddressEncoded, addr.PublicKey, true)
+		}
 		return nil, errors.New("Private Key is missing")
 	}
 
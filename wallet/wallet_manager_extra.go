@@ -147,6 +147,10 @@ func (wallet *Wallet) updateWallet() {
 //it must be locked and use original walletAddresses, not cloned ones
 func (wallet *Wallet) refreshWalletAccount(plainAcc *plain_account.PlainAccount, chainHeight uint64, addr *wallet_address.WalletAddress) (err error) {
 
+	if addr.IsWatchOnly {
+		return
+	}
+
 	deleted := false
 
 	if plainAcc == nil || !plainAcc.DelegatedStake.HasDelegatedStake() || addr.SharedStaked == nil {
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func createTestWallet(t *testing.T) *Wallet {
+
+	var err error
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.Nil(t, err)
+	}
+
+	db, err := store_db_memory.CreateStoreDBMemory("wallet")
+	assert.Nil(t, err)
+	store.StoreWallet = &store.Store{Name: "wallet", Opened: true, DB: db}
+
+	wallet := createWallet(nil, nil, nil)
+	wallet.setLoaded(true)
+	return wallet
+}
+
+func TestWallet_ImportWatchOnlyAddress(t *testing.T) {
+
+	wallet := createTestWallet(t)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	privateKey, err := addresses.NewPrivateKey(key)
+	assert.Nil(t, err)
+
+	address, err := privateKey.GenerateAddress(nil, 0, nil)
+	assert.Nil(t, err)
+
+	_, key2, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	privateKey2, err := addresses.NewPrivateKey(key2)
+	assert.Nil(t, err)
+
+	_, err = wallet.ImportWatchOnlyAddress("", "", nil, true)
+	assert.NotNil(t, err)
+
+	_, err = wallet.ImportWatchOnlyAddress("", address.EncodeAddr(), privateKey2.GeneratePublicKey(), true)
+	assert.NotNil(t, err, "public key not matching the address is rejected")
+
+	addr, err := wallet.ImportWatchOnlyAddress("cold", address.EncodeAddr(), nil, true)
+	assert.Nil(t, err)
+	assert.True(t, addr.IsWatchOnly)
+	assert.Nil(t, addr.PrivateKey)
+	assert.Nil(t, addr.PublicKey)
+	assert.Equal(t, privateKey.GeneratePublicKeyHash(), addr.PublicKeyHash)
+	assert.Equal(t, address.EncodeAddr(), addr.AddressEncoded)
+
+	_, err = addr.SignMessage([]byte{1})
+	assert.NotNil(t, err)
+	_, err = addr.VerifySignedMessage([]byte{1}, []byte{2})
+	assert.NotNil(t, err)
+
+	_, err = wallet.ImportWatchOnlyAddress("", "", privateKey.GeneratePublicKey(), true)
+	assert.NotNil(t, err, "the address already exists")
+
+	addr2, err := wallet.ImportWatchOnlyAddress("", "", privateKey2.GeneratePublicKey(), true)
+	assert.Nil(t, err)
+	assert.Equal(t, privateKey2.GeneratePublicKeyHash(), addr2.PublicKeyHash)
+
+	message := []byte("message")
+	signature, err := privateKey2.Sign(message)
+	assert.Nil(t, err)
+	valid, err := addr2.VerifySignedMessage(message, signature)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	assert.Equal(t, 2, wallet.Count)
+
+}
+
+func TestWallet_ExportWatchOnlyAddresses(t *testing.T) {
+
+	wallet := createTestWallet(t)
+
+	keys := make([]*addresses.PrivateKey, 2)
+	for i := range keys {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		assert.Nil(t, err)
+		keys[i], err = addresses.NewPrivateKey(key)
+		assert.Nil(t, err)
+	}
+
+	address, err := keys[0].GenerateAddress(nil, 0, nil)
+	assert.Nil(t, err)
+
+	_, err = wallet.ImportWatchOnlyAddress("first", address.EncodeAddr(), nil, true)
+	assert.Nil(t, err)
+	_, err = wallet.ImportWatchOnlyAddress("second", "", keys[1].GeneratePublicKey(), true)
+	assert.Nil(t, err)
+
+	exported := wallet.ExportWatchOnlyAddresses(true)
+	assert.Equal(t, 2, len(exported))
+
+	wallet2 := createTestWallet(t)
+	for _, it := range exported {
+		_, err = wallet2.ImportWatchOnlyAddress(it.Name, it.Address, it.PublicKey, true)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, len(wallet.Addresses), len(wallet2.Addresses))
+	for i, addr := range wallet.Addresses {
+		assert.Equal(t, addr.Name, wallet2.Addresses[i].Name)
+		assert.Equal(t, addr.AddressEncoded, wallet2.Addresses[i].AddressEncoded)
+		assert.Equal(t, addr.PublicKey, wallet2.Addresses[i].PublicKey)
+		assert.True(t, wallet2.Addresses[i].IsWatchOnly)
+	}
+
+}
@@ -211,6 +211,9 @@ func (wallet *Wallet) InitForgingWallet() (err error) {
 	}
 
 	for _, addr := range wallet.Addresses {
+		if addr.IsWatchOnly {
+			continue
+		}
 		if err = wallet.forging.Wallet.AddWallet(addr.PublicKeyHash, addr.SharedStaked, false, nil, 0); err != nil {
 			return
 		}